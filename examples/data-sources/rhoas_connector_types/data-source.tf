@@ -0,0 +1,60 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+variable "namespace_id" {}
+variable "kafka_id" {}
+variable "client_id" {}
+variable "client_secret" {
+  sensitive = true
+}
+
+data "rhoas_connector_types" "sinks" {
+  label   = "sink"
+  channel = "stable"
+}
+
+data "rhoas_connector_types" "log_sink" {
+  name = "Log Sink"
+}
+
+locals {
+  log_sink        = data.rhoas_connector_types.log_sink.connector_types[0]
+  log_sink_schema = jsondecode(local.log_sink.schema)
+
+  orders_logger = {
+    kafka_topic   = "orders"
+    data_shape    = { consumes = { format = "application/octet-stream" } }
+    processors    = []
+    error_handler = { stop = {} }
+  }
+}
+
+output "sink_ids" {
+  value = data.rhoas_connector_types.sinks.connector_types[*].id
+}
+
+resource "rhoas_connector" "orders_logger" {
+  name              = "orders-logger"
+  namespace_id      = var.namespace_id
+  connector_type_id = local.log_sink.id
+  kafka_id          = var.kafka_id
+  client_id         = var.client_id
+  client_secret     = var.client_secret
+  connector         = jsonencode(local.orders_logger)
+
+  lifecycle {
+    # check the required properties of the connector type are set at plan time
+    precondition {
+      condition     = length(setsubtract(try(local.log_sink_schema.required, []), keys(local.orders_logger))) == 0
+      error_message = "The orders logger lacks some of the required properties of the ${local.log_sink.name} connector type."
+    }
+  }
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+data "rhoas_consumer_group" "orders" {
+  kafka_id = data.rhoas_kafka.foo.id
+  group_id = "orders-processor"
+}
+
+check "orders_processor_lag" {
+  assert {
+    condition     = data.rhoas_consumer_group.orders.total_lag < 1000
+    error_message = "The orders processor is lagging behind."
+  }
+}
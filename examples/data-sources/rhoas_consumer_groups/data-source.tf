@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+data "rhoas_consumer_groups" "orders" {
+  kafka_id        = data.rhoas_kafka.foo.id
+  group_id_prefix = "orders-"
+  topic           = "orders"
+}
+
+output "lagging_consumer_groups" {
+  value = [for group in data.rhoas_consumer_groups.orders.consumer_groups : group.group_id if group.lagging_partitions > 0]
+}
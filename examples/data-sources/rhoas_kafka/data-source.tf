@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+output "bootstrap_server_foo" {
+  value = data.rhoas_kafka.foo.bootstrap_server_host
+}
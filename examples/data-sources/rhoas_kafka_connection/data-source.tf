@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_service_account" "foo" {
+  name = "foo-client"
+}
+
+data "rhoas_kafka_connection" "foo" {
+  kafka_id      = rhoas_kafka.foo.id
+  client_id     = rhoas_service_account.foo.client_id
+  client_secret = rhoas_service_account.foo.client_secret
+  format        = "properties"
+}
+
+output "client_properties" {
+  value     = data.rhoas_kafka_connection.foo.content
+  sensitive = true
+}
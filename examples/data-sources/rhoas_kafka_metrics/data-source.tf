@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka_metrics" "foo" {
+  kafka_id = "<kafka_id>"
+}
+
+check "foo_capacity" {
+  assert {
+    condition     = data.rhoas_kafka_metrics.foo.partitions <= 0.8 * data.rhoas_kafka_metrics.foo.partition_limit
+    error_message = "The Kafka instance uses more than 80% of its partition limit."
+  }
+
+  assert {
+    condition     = data.rhoas_kafka_metrics.foo.disk_used_bytes <= 0.8 * data.rhoas_kafka_metrics.foo.storage_limit_bytes
+    error_message = "The Kafka instance uses more than 80% of its storage limit."
+  }
+}
+
+data "rhoas_kafka_metrics" "foo_ingress" {
+  kafka_id = "<kafka_id>"
+  filters  = ["kafka_namespace:haproxy_server_bytes_in_total:rate5m"]
+  duration = "1h"
+  interval = "5m"
+}
+
+output "foo_ingress" {
+  value = data.rhoas_kafka_metrics.foo_ingress.series
+}
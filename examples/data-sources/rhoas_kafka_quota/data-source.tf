@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka_quota" "org" {
+}
+
+# prefer the marketplace billing model when the organization has quota left for it
+resource "rhoas_kafka" "foo" {
+  name          = "foo"
+  plan          = "standard.x1"
+  billing_model = contains(data.rhoas_kafka_quota.org.allowed_billing_models, "marketplace") ? "marketplace" : "standard"
+}
+
+output "remaining_quota" {
+  value = {
+    for quota in data.rhoas_kafka_quota.org.quotas : quota.billing_model => quota.remaining...
+  }
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name           = "foo"
+  plan           = "developer.x1"
+  billing_model  = "standard"
+  wait_for_ready = false
+}
+
+data "rhoas_kafka_ready" "foo" {
+  kafka_id = rhoas_kafka.foo.id
+}
+
+resource "rhoas_topic" "topic" {
+  name       = "topic-1"
+  partitions = 1
+  kafka_id   = data.rhoas_kafka_ready.foo.id
+}
+
+output "bootstrap_server_foo" {
+  value = data.rhoas_kafka_ready.foo.bootstrap_server_host
+}
@@ -12,6 +12,20 @@ provider "rhoas" {}
 data "rhoas_kafkas" "all" {
 }
 
+data "rhoas_kafkas" "ready_in_us_east" {
+  status         = "ready"
+  cloud_provider = "aws"
+  region         = "us-east-1"
+}
+
+data "rhoas_kafkas" "test_instances" {
+  name = "test-%"
+}
+
 output "all_kafkas" {
   value = data.rhoas_kafkas.all
 }
+
+output "ready_kafkas_in_us_east" {
+  value = data.rhoas_kafkas.ready_in_us_east.kafkas[*].name
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_service_registries" "all" {
+}
+
+data "rhoas_service_registries" "test_registries" {
+  name = "test-%"
+}
+
+output "all_registry_urls" {
+  value = data.rhoas_service_registries.all.registries[*].registry_url
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_service_registry" "schemas" {
+  name = "schemas"
+}
+
+output "registry_url_schemas" {
+  value = data.rhoas_service_registry.schemas.registry_url
+}
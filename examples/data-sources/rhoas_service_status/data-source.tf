@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_service_status" "us_east_1" {
+  cloud_provider = "aws"
+  region         = "us-east-1"
+  plan           = "developer.x1"
+}
+
+# only create the ephemeral instance when the region has capacity for it
+resource "rhoas_kafka" "ephemeral" {
+  count = data.rhoas_service_status.us_east_1.kafkas_creatable ? 1 : 0
+
+  name          = "ephemeral"
+  plan          = "developer.x1"
+  billing_model = "standard"
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_topic_records" "orders" {
+  kafka_id  = "<kafka_id>"
+  topic     = "orders"
+  partition = 0
+  offset    = 0
+  limit     = 10
+}
+
+check "orders_seeded" {
+  assert {
+    condition     = length(data.rhoas_topic_records.orders.records) > 0
+    error_message = "The orders topic contains no records."
+  }
+}
+
+output "order_keys" {
+  value = data.rhoas_topic_records.orders.records[*].key
+}
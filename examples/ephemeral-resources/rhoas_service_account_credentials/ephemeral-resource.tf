@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+    kafka = {
+      source = "Mongey/kafka"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "example" {
+  id = "cd4dpmuj2i1ac9ikq4q0"
+}
+
+# The secret is reset every time Terraform opens the ephemeral resource, i.e.
+# for every plan and apply
+ephemeral "rhoas_service_account_credentials" "admin" {
+  service_account_id = "9b1d8c3e-3f4a-4a52-b6f2-5c1e2d7f8a90"
+}
+
+provider "kafka" {
+  bootstrap_servers = [data.rhoas_kafka.example.bootstrap_server_host]
+  tls_enabled       = true
+  sasl_mechanism    = "plain"
+  sasl_username     = ephemeral.rhoas_service_account_credentials.admin.client_id
+  sasl_password     = ephemeral.rhoas_service_account_credentials.admin.client_secret
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+locals {
+  kafka_id = "cd4dpmuj2i1ac9ikq4q0"
+  topics   = ["orders", "payments"]
+}
+
+# "cd4dpmuj2i1ac9ikq4q0/User:*/TOPIC/orders/LITERAL/READ/ALLOW", ...
+output "read_acl_ids" {
+  value = [
+    for topic in local.topics :
+    provider::rhoas::acl_id(local.kafka_id, "*", "TOPIC", topic, "LITERAL", "READ", "ALLOW")
+  ]
+}
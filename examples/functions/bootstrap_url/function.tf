@@ -0,0 +1,26 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "example" {
+  id = "cd4dpmuj2i1ac9ikq4q0"
+}
+
+# e.g. "example-cd4dpmuj2i1ac9ikq4q0.bf2.kafka.rhcloud.com:443"
+output "bootstrap_url" {
+  value = provider::rhoas::bootstrap_url(data.rhoas_kafka.example.bootstrap_server_host, null)
+}
+
+# e.g. "example-cd4dpmuj2i1ac9ikq4q0.bf2.kafka.rhcloud.com:9096"
+output "bootstrap_url_with_port" {
+  value = provider::rhoas::bootstrap_url(data.rhoas_kafka.example.bootstrap_server_host, 9096)
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+variable "plan" {
+  type    = string
+  default = "standard.x2"
+}
+
+locals {
+  plan = provider::rhoas::parse_plan(var.plan)
+}
+
+# "standard"
+output "instance_type" {
+  value = local.plan.instance_type
+}
+
+# "x2"
+output "size" {
+  value = local.plan.size
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+resource "rhoas_service_account" "app" {
+  name        = "app"
+  description = "service account of the app"
+}
+
+# "User:srvc-acct-..."
+output "app_principal" {
+  value = provider::rhoas::principal(rhoas_service_account.app.client_id)
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+variable "kafka_name" {
+  type = string
+
+  validation {
+    condition     = provider::rhoas::valid_kafka_name(var.kafka_name)
+    error_message = "The Kafka instance name must consist of up to 32 lowercase letters, digits and hyphens."
+  }
+}
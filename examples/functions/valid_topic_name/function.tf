@@ -0,0 +1,19 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+variable "topic_name" {
+  type = string
+
+  validation {
+    condition     = provider::rhoas::valid_topic_name(var.topic_name)
+    error_message = "The topic name must consist of letters, digits, periods, underscores and hyphens."
+  }
+}
@@ -0,0 +1,2 @@
+# Bridges are imported using their ID, the error_handler argument is not read back
+terraform import rhoas_bridge.shop <bridge_id>
@@ -0,0 +1,45 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "events" {
+  name = "events"
+}
+
+resource "rhoas_service_account" "bridge" {
+  name        = "bridge"
+  description = "Writes the events the bridge fails to process"
+}
+
+resource "rhoas_topic" "dead_letters" {
+  name       = "dead-letters"
+  partitions = 1
+  kafka_id   = rhoas_kafka.events.id
+}
+
+resource "rhoas_bridge" "shop" {
+  name           = "shop"
+  cloud_provider = "aws"
+  region         = "us-east-1"
+
+  # send the events the bridge fails to process to a dead letter topic
+  error_handler {
+    kafka_topic {
+      kafka_id      = rhoas_kafka.events.id
+      topic         = rhoas_topic.dead_letters.name
+      client_id     = rhoas_service_account.bridge.client_id
+      client_secret = rhoas_service_account.bridge.client_secret
+    }
+  }
+}
+
+output "shop_endpoint" {
+  value = rhoas_bridge.shop.endpoint
+}
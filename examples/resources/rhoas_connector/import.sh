@@ -0,0 +1,2 @@
+# Connectors are imported using their ID, the client_secret and connector arguments are not read back
+terraform import rhoas_connector.orders_logger <connector_id>
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "orders" {
+  name = "orders"
+}
+
+resource "rhoas_service_account" "orders_logger" {
+  name        = "orders-logger"
+  description = "Logs the order events"
+}
+
+resource "rhoas_connector_namespace" "eval" {
+  name = "eval-namespace"
+}
+
+resource "rhoas_connector" "orders_logger" {
+  name              = "orders-logger"
+  namespace_id      = rhoas_connector_namespace.eval.id
+  connector_type_id = "log_sink_0.1"
+  kafka_id          = rhoas_kafka.orders.id
+  client_id         = rhoas_service_account.orders_logger.client_id
+  client_secret     = rhoas_service_account.orders_logger.client_secret
+
+  connector = jsonencode({
+    kafka_topic   = "orders"
+    data_shape    = { consumes = { format = "application/octet-stream" } }
+    processors    = []
+    error_handler = { stop = {} }
+  })
+
+  desired_state = "ready"
+}
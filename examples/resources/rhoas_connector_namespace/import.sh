@@ -0,0 +1,2 @@
+# Connector namespaces are imported using their ID
+terraform import rhoas_connector_namespace.eval <namespace_id>
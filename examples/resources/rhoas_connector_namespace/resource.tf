@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+# Without a cluster_id an evaluation namespace is created
+resource "rhoas_connector_namespace" "eval" {
+  name = "eval-namespace"
+}
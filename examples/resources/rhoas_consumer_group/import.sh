@@ -0,0 +1,2 @@
+# Consumer groups are imported using the ID of the Kafka instance and the ID of the group, separated by a slash
+terraform import rhoas_consumer_group.orders <kafka_id>/orders-processor
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_consumer_group" "orders" {
+  kafka_id = data.rhoas_kafka.foo.id
+  group_id = "orders-processor"
+}
+
+output "orders_processor_members" {
+  value = rhoas_consumer_group.orders.members
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_consumer_group_offset_reset" "replay_orders" {
+  kafka_id = data.rhoas_kafka.foo.id
+  group_id = "orders-processor"
+  strategy = "timestamp"
+  value    = "2022-06-01T00:00:00Z"
+  topic    = "orders"
+
+  # change the release to replay the orders again
+  triggers = {
+    release = "2022-06-01"
+  }
+}
+
+output "reset_offsets" {
+  value = rhoas_consumer_group_offset_reset.replay_orders.offsets
+}
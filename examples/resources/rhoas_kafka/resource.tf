@@ -13,6 +13,8 @@ resource "rhoas_kafka" "foo" {
   name = "foo"
   plan = "developer.x1"
   billing_model = "standard"
+  on_failure = "delete"
+  deletion_protection = true
 }
 
 output "bootstrap_server_foo" {
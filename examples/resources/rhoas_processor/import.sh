@@ -0,0 +1,2 @@
+# Processors are imported using the ID of their bridge and their ID, separated by a slash. The action argument is not read back
+terraform import rhoas_processor.orders <bridge_id>/<processor_id>
@@ -0,0 +1,71 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "events" {
+  name = "events"
+}
+
+resource "rhoas_service_account" "processor" {
+  name        = "processor"
+  description = "Writes the orders of the shop"
+}
+
+resource "rhoas_topic" "orders" {
+  name       = "orders"
+  partitions = 3
+  kafka_id   = rhoas_kafka.events.id
+}
+
+resource "rhoas_bridge" "shop" {
+  name = "shop"
+}
+
+# send the orders of the shop from France or Germany to the orders topic
+resource "rhoas_processor" "orders" {
+  bridge_id = rhoas_bridge.shop.id
+  name      = "orders"
+
+  filter {
+    type  = "StringEquals"
+    key   = "type"
+    value = "order"
+  }
+
+  filter {
+    type   = "StringIn"
+    key    = "data.country"
+    values = ["FR", "DE"]
+  }
+
+  transformation_template = "{data.name} ordered {data.count} items"
+
+  action {
+    kafka_topic {
+      kafka_id      = rhoas_kafka.events.id
+      topic         = rhoas_topic.orders.name
+      client_id     = rhoas_service_account.processor.client_id
+      client_secret = rhoas_service_account.processor.client_secret
+    }
+  }
+}
+
+# send every event of the shop to a webhook
+resource "rhoas_processor" "audit" {
+  bridge_id = rhoas_bridge.shop.id
+  name      = "audit"
+
+  action {
+    type = "webhook_sink_0.1"
+    parameters = {
+      endpoint = "https://audit.example.com/events"
+    }
+  }
+}
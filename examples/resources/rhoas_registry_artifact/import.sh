@@ -0,0 +1,2 @@
+# Artifacts are imported using the ID of the Service Registry instance, the group and the ID of the artifact, separated by slashes
+terraform import rhoas_registry_artifact.orders_value <registry_id>/orders/orders-value
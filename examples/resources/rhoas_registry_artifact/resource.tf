@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_service_registry" "schemas" {
+  name = "schemas"
+}
+
+resource "rhoas_registry_artifact" "orders_value" {
+  registry_id        = rhoas_service_registry.schemas.id
+  group_id           = "orders"
+  artifact_id        = "orders-value"
+  type               = "AVRO"
+  compatibility_rule = "BACKWARD"
+  validity_rule      = "FULL"
+
+  content = jsonencode({
+    type      = "record"
+    name      = "Order"
+    namespace = "com.example"
+    fields = [
+      { name = "id", type = "string" },
+      { name = "total", type = "double", default = 0 },
+    ]
+  })
+}
+
+output "orders_value_global_id" {
+  value = rhoas_registry_artifact.orders_value.global_id
+}
@@ -0,0 +1,2 @@
+# Role mappings are imported using the ID of the Service Registry instance and the ID of the principal, separated by a slash
+terraform import rhoas_registry_role_mapping.orders_producer <registry_id>/<client_id>
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_service_registry" "schemas" {
+  name = "schemas"
+}
+
+resource "rhoas_service_account" "orders_producer" {
+  name        = "orders-producer"
+  description = "Produces the order events"
+}
+
+resource "rhoas_registry_role_mapping" "orders_producer" {
+  registry_id    = rhoas_service_registry.schemas.id
+  principal_id   = rhoas_service_account.orders_producer.client_id
+  principal_name = rhoas_service_account.orders_producer.name
+  role           = "READ_ONLY"
+}
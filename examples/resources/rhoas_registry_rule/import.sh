@@ -0,0 +1,2 @@
+# Rules are imported using the ID of the Service Registry instance and the type of the rule, separated by a slash
+terraform import rhoas_registry_rule.validity <registry_id>/VALIDITY
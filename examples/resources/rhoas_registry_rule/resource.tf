@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_service_registry" "schemas" {
+  name = "schemas"
+}
+
+# applies to every artifact of the registry without a rule of its own
+resource "rhoas_registry_rule" "validity" {
+  registry_id = rhoas_service_registry.schemas.id
+  type        = "VALIDITY"
+  config      = "FULL"
+}
+
+resource "rhoas_registry_rule" "compatibility" {
+  registry_id = rhoas_service_registry.schemas.id
+  type        = "COMPATIBILITY"
+  config      = "BACKWARD_TRANSITIVE"
+}
@@ -0,0 +1,2 @@
+# Service Registry instances are imported using their ID
+terraform import rhoas_service_registry.schemas <registry_id>
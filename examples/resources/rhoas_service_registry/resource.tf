@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_service_registry" "schemas" {
+  name        = "schemas"
+  description = "Schemas of the order events"
+}
+
+output "registry_url_schemas" {
+  value = rhoas_service_registry.schemas.registry_url
+}
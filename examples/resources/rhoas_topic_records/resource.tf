@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "registry.terraform.io/redhat-developer/rhoas"
+      version = "0.3"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_topic" "orders" {
+  name       = "orders"
+  partitions = 3
+  kafka_id   = rhoas_kafka.foo.id
+}
+
+resource "rhoas_topic_records" "orders" {
+  kafka_id = rhoas_kafka.foo.id
+  topic    = rhoas_topic.orders.name
+
+  record {
+    key   = "order-1"
+    value = jsonencode({ item = "book", quantity = 1 })
+    headers = {
+      source = "fixtures"
+    }
+  }
+
+  record {
+    key       = "order-2"
+    value     = jsonencode({ item = "pen", quantity = 3 })
+    partition = 2
+  }
+}
+
+output "published_orders" {
+  value = rhoas_topic_records.orders.published_records
+}
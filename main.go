@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas"
 )
 
@@ -13,9 +16,20 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuging")
 	flag.Parse()
 
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: rhoas.Provider,
-		ProviderAddr: "registry.terraform.io/redhat-developer/rhoas",
-		Debug:        debug,
-	})
+	ctx := context.Background()
+
+	providerServer, err := rhoas.ProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/redhat-developer/rhoas", providerServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
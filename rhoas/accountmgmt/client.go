@@ -0,0 +1,129 @@
+package accountmgmt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
+)
+
+const (
+	basePath = "/api/accounts_mgmt/v1"
+
+	// the number of quota costs requested for each page of the list api
+	quotaCostPageSize = 100
+)
+
+// APIClient calls the parts of the OpenShift Cluster Manager account management
+// api used by the provider. It stands in for the accountmgmt module of the app
+// services sdk, which the provider does not depend on yet
+type APIClient struct {
+	client *restapi.Client
+}
+
+// Account is the account of the authenticated user
+type Account struct {
+	ID           string       `json:"id"`
+	Username     string       `json:"username"`
+	Organization Organization `json:"organization"`
+}
+
+// Organization is the organization an account belongs to
+type Organization struct {
+	ID         string `json:"id"`
+	ExternalID string `json:"external_id"`
+	Name       string `json:"name"`
+}
+
+// QuotaCostList is a page of the quota costs of an organization
+type QuotaCostList struct {
+	Page  int         `json:"page"`
+	Size  int         `json:"size"`
+	Total int         `json:"total"`
+	Items []QuotaCost `json:"items"`
+}
+
+// QuotaCost is a quota of an organization and how much of it is consumed
+type QuotaCost struct {
+	QuotaID          string            `json:"quota_id"`
+	Allowed          int               `json:"allowed"`
+	Consumed         int               `json:"consumed"`
+	RelatedResources []RelatedResource `json:"related_resources"`
+	CloudAccounts    []CloudAccount    `json:"cloud_accounts"`
+}
+
+// RelatedResource is a resource which consumes a quota
+type RelatedResource struct {
+	ResourceName string `json:"resource_name"`
+	ResourceType string `json:"resource_type"`
+	Product      string `json:"product"`
+	BillingModel string `json:"billing_model"`
+	Cost         int    `json:"cost"`
+}
+
+// CloudAccount is a cloud marketplace account which can be billed for a quota
+type CloudAccount struct {
+	CloudAccountID  string `json:"cloud_account_id"`
+	CloudProviderID string `json:"cloud_provider_id"`
+}
+
+// API is the part of the account management api used by the provider. The
+// factory returns it rather than the APIClient so that it can be replaced in tests
+type API interface {
+	GetCurrentAccount(ctx context.Context) (Account, *http.Response, error)
+	GetQuotaCosts(ctx context.Context, organizationID string, page int) (QuotaCostList, *http.Response, error)
+	ListQuotaCosts(ctx context.Context, organizationID string) ([]QuotaCost, *http.Response, error)
+}
+
+var _ API = &APIClient{}
+
+func NewAPIClient(httpClient *http.Client, baseURL string) *APIClient {
+	return &APIClient{
+		client: restapi.NewClient(httpClient, baseURL),
+	}
+}
+
+// GetCurrentAccount returns the account of the authenticated user
+func (c *APIClient) GetCurrentAccount(ctx context.Context) (Account, *http.Response, error) {
+	var account Account
+	resp, err := c.client.Get(ctx, basePath+"/current_account", nil, &account)
+
+	return account, resp, err
+}
+
+// GetQuotaCosts returns a page of the quota costs of the organization with
+// the given id, including the resources consuming them and the cloud accounts
+// which can be billed for them
+func (c *APIClient) GetQuotaCosts(ctx context.Context, organizationID string, page int) (QuotaCostList, *http.Response, error) {
+	query := url.Values{}
+	query.Set("fetchRelatedResources", "true")
+	query.Set("fetchCloudAccounts", "true")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(quotaCostPageSize))
+
+	var list QuotaCostList
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/organizations/%s/quota_cost", basePath, url.PathEscape(organizationID)), query, &list)
+
+	return list, resp, err
+}
+
+// ListQuotaCosts returns all of the quota costs of the organization with the given id
+func (c *APIClient) ListQuotaCosts(ctx context.Context, organizationID string) ([]QuotaCost, *http.Response, error) {
+	quotaCosts := make([]QuotaCost, 0)
+
+	for page := 1; ; page++ {
+		list, resp, err := c.GetQuotaCosts(ctx, organizationID, page)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		quotaCosts = append(quotaCosts, list.Items...)
+
+		if len(list.Items) < quotaCostPageSize || len(quotaCosts) >= list.Total {
+			return quotaCosts, resp, nil
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package acl
+
+import (
+	"strings"
+)
+
+// Principal returns the principal of an acl binding for a user id, a service
+// account client id or "*", which the api expects to be prefixed with "User:"
+func Principal(id string) string {
+	if strings.HasPrefix(id, PrincipalPrefix) {
+		return id
+	}
+
+	return PrincipalPrefix + id
+}
+
+// BindingID returns the id of an acl binding. Acl bindings have no id in the
+// api, so the id is made of the kafka instance and of the fields of the
+// binding, which identify it
+func BindingID(kafkaID string, principal string, resourceType string, resourceName string, patternType string, operationType string, permissionType string) string {
+	return strings.Join([]string{
+		kafkaID,
+		Principal(principal),
+		resourceType,
+		resourceName,
+		patternType,
+		operationType,
+		permissionType,
+	}, "/")
+}
@@ -0,0 +1,18 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	assert.Equal(t, "User:jdoe", Principal("jdoe"))
+	assert.Equal(t, "User:*", Principal("*"))
+	assert.Equal(t, "User:jdoe", Principal("User:jdoe"))
+}
+
+func TestBindingID(t *testing.T) {
+	assert.Equal(t, "test-kafka-id/User:*/GROUP/*/LITERAL/ALL/ALLOW", BindingID("test-kafka-id", "*", "GROUP", "*", "LITERAL", "ALL", "ALLOW"))
+	assert.Equal(t, BindingID("test-kafka-id", "jdoe", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"), BindingID("test-kafka-id", "User:jdoe", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"))
+}
@@ -0,0 +1,65 @@
+package acl
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+var _ function.Function = &aclIDFunction{}
+
+// aclIDFunction is the acl_id provider function, returning the id of the
+// rhoas_acl resource managing an acl binding
+type aclIDFunction struct {
+	localizer localize.Localizer
+}
+
+func NewACLIDFunction(localizer localize.Localizer) function.Function {
+	return &aclIDFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *aclIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "acl_id"
+}
+
+func (f *aclIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	parameters := make([]function.Parameter, 0, 7)
+	for _, field := range []struct {
+		name          string
+		descriptionID string
+	}{
+		{KafkaIDField, "acl.resource.field.description.kafkaID"},
+		{PrincipalField, "acl.resource.field.description.principal"},
+		{ResourceTypeField, "acl.resource.field.description.resourceType"},
+		{ResourceNameField, "acl.resource.field.description.resourceName"},
+		{PatternTypeField, "acl.resource.field.description.patternType"},
+		{OperationTypeField, "acl.resource.field.description.operationType"},
+		{PermissionTypeField, "acl.resource.field.description.permissionType"},
+	} {
+		parameters = append(parameters, function.StringParameter{
+			Name:        field.name,
+			Description: f.localizer.MustLocalize(field.descriptionID),
+		})
+	}
+
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("acl.function.aclID.summary"),
+		Description: f.localizer.MustLocalize("acl.function.aclID.description"),
+		Parameters:  parameters,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f *aclIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var kafkaID, principal, resourceType, resourceName, patternType, operationType, permissionType string
+
+	resp.Error = req.Arguments.Get(ctx, &kafkaID, &principal, &resourceType, &resourceName, &patternType, &operationType, &permissionType)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, BindingID(kafkaID, principal, resourceType, resourceName, patternType, operationType, permissionType))
+}
@@ -0,0 +1,51 @@
+package acl
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+var _ function.Function = &principalFunction{}
+
+// principalFunction is the principal provider function, returning the
+// principal of an acl binding as built by the resources
+type principalFunction struct {
+	localizer localize.Localizer
+}
+
+func NewPrincipalFunction(localizer localize.Localizer) function.Function {
+	return &principalFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *principalFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "principal"
+}
+
+func (f *principalFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("acl.function.principal.summary"),
+		Description: f.localizer.MustLocalize("acl.function.principal.description"),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "client_id",
+				Description: f.localizer.MustLocalize("acl.function.principal.parameter.clientID"),
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *principalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var clientID string
+
+	resp.Error = req.Arguments.Get(ctx, &clientID)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, Principal(clientID))
+}
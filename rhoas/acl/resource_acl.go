@@ -2,21 +2,26 @@ package acl
 
 import (
 	"context"
-	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
-	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
-	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
-	"math/rand"
-	"strconv"
+	"fmt"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
 	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
 )
 
 const (
 	PrincipalPrefix = "User:"
 
+	IDField             = "id"
 	PrincipalField      = "principal"
 	KafkaIDField        = "kafka_id"
 	ResourceTypeField   = "resource_type"
@@ -24,157 +29,207 @@ const (
 	PatternTypeField    = "pattern_type"
 	OperationTypeField  = "operation_type"
 	PermissionTypeField = "permission_type"
+	TimeoutsField       = "timeouts"
+
+	defaultCreateTimeout = 20 * time.Minute
 )
 
-func ResourceACL(localizer localize.Localizer) *schema.Resource {
-	return &schema.Resource{
-		Description:   "`rhoas_acl` manages an ACL binding for a Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
-		CreateContext: aclCreate,
-		ReadContext:   aclRead,
-		DeleteContext: aclDelete,
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(20 * time.Minute),
-		},
-		Schema: map[string]*schema.Schema{
-			PrincipalField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.principal"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			KafkaIDField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.kafkaID"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			ResourceTypeField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.resourceType"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			ResourceNameField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.resourceName"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			PatternTypeField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.patternType"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			OperationTypeField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.operationType"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			PermissionTypeField: {
-				Description: localizer.MustLocalize("acl.resource.field.description.permissionType"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-		},
+var (
+	_ resource.ResourceWithConfigure  = &aclResource{}
+	_ resource.ResourceWithModifyPlan = &aclResource{}
+)
+
+// aclResource is the rhoas_acl resource, served by the plugin framework half
+// of the provider. Its schema is the one of the sdk resource it replaces, so
+// the states written by the sdk resource are read as they are
+type aclResource struct {
+	localizer localize.Localizer
+	factory   rhoasAPI.Factory
+}
+
+// aclModel maps the attributes of the rhoas_acl resource
+type aclModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Principal      types.String   `tfsdk:"principal"`
+	KafkaID        types.String   `tfsdk:"kafka_id"`
+	ResourceType   types.String   `tfsdk:"resource_type"`
+	ResourceName   types.String   `tfsdk:"resource_name"`
+	PatternType    types.String   `tfsdk:"pattern_type"`
+	OperationType  types.String   `tfsdk:"operation_type"`
+	PermissionType types.String   `tfsdk:"permission_type"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewResourceACL(localizer localize.Localizer) resource.Resource {
+	return &aclResource{
+		localizer: localizer,
 	}
 }
 
-func aclDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
+func (r *aclResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl"
+}
 
-	return diags
+func (r *aclResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`rhoas_acl` manages an ACL binding for a Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
+		Attributes: map[string]schema.Attribute{
+			IDField: schema.StringAttribute{
+				Description: "The ID of this resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			PrincipalField:      r.requiredAttribute("acl.resource.field.description.principal"),
+			KafkaIDField:        r.requiredAttribute("acl.resource.field.description.kafkaID"),
+			ResourceTypeField:   r.requiredAttribute("acl.resource.field.description.resourceType"),
+			ResourceNameField:   r.requiredAttribute("acl.resource.field.description.resourceName"),
+			PatternTypeField:    r.requiredAttribute("acl.resource.field.description.patternType"),
+			OperationTypeField:  r.requiredAttribute("acl.resource.field.description.operationType"),
+			PermissionTypeField: r.requiredAttribute("acl.resource.field.description.permissionType"),
+		},
+		Blocks: map[string]schema.Block{
+			TimeoutsField: timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
 }
 
-func aclRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+// requiredAttribute returns a required string attribute replacing the acl
+// binding when changed, as acl bindings cannot be updated
+func (r *aclResource) requiredAttribute(descriptionID string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: r.localizer.MustLocalize(descriptionID),
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
 }
 
-func aclCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
+func (r *aclResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the provider data is not set when the resource is validated
+	if req.ProviderData == nil {
+		return
+	}
 
-	factory, ok := m.(rhoasAPI.Factory)
+	factory, ok := req.ProviderData.(rhoasAPI.Factory)
 	if !ok {
-		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+		resp.Diagnostics.AddError(r.localizer.MustLocalize("common.errors.configure"), fmt.Sprintf("unable to cast %v to rhoasAPI.Factory", req.ProviderData))
+		return
 	}
 
-	kafkaID, ok := d.Get(KafkaIDField).(string)
-	if !ok {
-		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	r.factory = factory
+}
+
+// ModifyPlan checks the kafka instance and the principal of a new or replaced
+// acl binding when the provider validates references
+func (r *aclResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.factory == nil || !r.factory.ValidateReferences() {
+		return
 	}
 
-	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
-	if err != nil {
-		return diag.FromErr(err)
+	var plan aclModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	state := aclModel{
+		KafkaID:   types.StringNull(),
+		Principal: types.StringNull(),
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	}
 
-	binding, err := mapResourceDataToACLBinding(factory, d)
-	if err != nil {
-		return diag.FromErr(err)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	resp, err := instanceAPI.AclsApi.CreateAcl(ctx).AclBinding(*binding).Execute()
-	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
-		return diag.FromErr(apiErr)
+	if referenceChanged(state.KafkaID, plan.KafkaID) {
+		if err := utils.CheckKafkaReference(ctx, r.factory, KafkaIDField, plan.KafkaID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(KafkaIDField), r.localizer.MustLocalize("common.errors.invalidReference"), err.Error())
+		}
 	}
 
-	// acls have no id so we need to create a new random one for it
-	idNumber := rand.Intn(1_000_000_000) //nolint:gosec
-	d.SetId(kafkaID + strconv.Itoa(idNumber))
+	if referenceChanged(state.Principal, plan.Principal) {
+		if err := utils.CheckPrincipalReference(ctx, r.factory, PrincipalField, plan.Principal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(PrincipalField), r.localizer.MustLocalize("common.errors.invalidReference"), err.Error())
+		}
+	}
+}
 
-	return diags
+// referenceChanged returns whether the planned reference is known and either
+// new or changed, unchanged references are checked when read
+func referenceChanged(state types.String, plan types.String) bool {
+	return !plan.IsUnknown() && !plan.IsNull() && plan.ValueString() != "" && !plan.Equal(state)
 }
 
-func mapResourceDataToACLBinding(factory rhoasAPI.Factory, d *schema.ResourceData) (*kafkainstanceclient.AclBinding, error) {
+func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan aclModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// we only set these values from the resource data as all the rest are set as
-	// computed in the schema and for us the computed values are assigned when we
-	// get the kafka request object back from the API
-	principal, ok := d.Get(PrincipalField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalField))
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	principal = PrincipalPrefix + principal
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	resourceType, ok := d.Get(ResourceTypeField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ResourceTypeField))
-	}
+	kafkaID := plan.KafkaID.ValueString()
 
-	resourceName, ok := d.Get(ResourceNameField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ResourceNameField))
+	instanceAPI, _, err := r.factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		resp.Diagnostics.AddError(r.localizer.MustLocalize("acl.resource.errors.create"), err.Error())
+		return
 	}
 
-	patternType, ok := d.Get(PatternTypeField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PatternTypeField))
+	httpResp, err := instanceAPI.AclsApi.CreateAcl(ctx).AclBinding(*mapModelToACLBinding(plan)).Execute()
+	if apiErr := utils.GetAPIError(r.factory, httpResp, err); apiErr != nil {
+		resp.Diagnostics.AddError(r.localizer.MustLocalize("acl.resource.errors.create"), apiErr.Error())
+		return
 	}
 
-	operationType, ok := d.Get(OperationTypeField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", OperationTypeField))
-	}
+	plan.ID = types.StringValue(BindingID(
+		kafkaID,
+		plan.Principal.ValueString(),
+		plan.ResourceType.ValueString(),
+		plan.ResourceName.ValueString(),
+		plan.PatternType.ValueString(),
+		plan.OperationType.ValueString(),
+		plan.PermissionType.ValueString(),
+	))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	permissionType, ok := d.Get(PermissionTypeField).(string)
-	if !ok {
-		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PermissionTypeField))
-	}
+func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// acl bindings cannot be looked up by id, so the state is kept as it is
+}
 
-	binding := kafkainstanceclient.NewAclBinding(
-		kafkainstanceclient.AclResourceType(resourceType),
-		resourceName,
-		kafkainstanceclient.AclPatternType(patternType),
-		principal,
-		kafkainstanceclient.AclOperation(operationType),
-		kafkainstanceclient.AclPermissionType(permissionType),
-	)
+func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute requires the acl binding to be replaced, only the
+	// timeouts can be updated
+	var plan aclModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	return binding, nil
+func (r *aclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func mapModelToACLBinding(model aclModel) *kafkainstanceclient.AclBinding {
+	return kafkainstanceclient.NewAclBinding(
+		kafkainstanceclient.AclResourceType(model.ResourceType.ValueString()),
+		model.ResourceName.ValueString(),
+		kafkainstanceclient.AclPatternType(model.PatternType.ValueString()),
+		Principal(model.Principal.ValueString()),
+		kafkainstanceclient.AclOperation(model.OperationType.ValueString()),
+		kafkainstanceclient.AclPermissionType(model.PermissionType.ValueString()),
+	)
 }
@@ -0,0 +1,149 @@
+package acl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// testACLResource returns the acl resource configured with a factory for the
+// ready fake kafka instance, whose admin api records the created acl bindings
+func testACLResource(t *testing.T, validateReferences bool) (*aclResource, *[]kafkainstanceclient.AclBinding) {
+	var bindings []kafkainstanceclient.AclBinding
+
+	factory := fakeapi.KafkaInstanceFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/acls" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var binding kafkainstanceclient.AclBinding
+		_ = json.NewDecoder(r.Body).Decode(&binding)
+		bindings = append(bindings, binding)
+		w.WriteHeader(http.StatusCreated)
+	}, func(o *factories.Options) {
+		o.ValidateReferences = validateReferences
+	})
+
+	r, _ := NewResourceACL(factory.Localizer()).(*aclResource)
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: factory}, &resource.ConfigureResponse{})
+
+	return r, &bindings
+}
+
+func testACLSchema(t *testing.T, r *aclResource) resource.SchemaResponse {
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	assert.Empty(t, resp.Diagnostics, "got unexpected diagnostics")
+
+	return resp
+}
+
+func testACLModel(kafkaID string, principal string) aclModel {
+	return aclModel{
+		ID:             types.StringUnknown(),
+		Principal:      types.StringValue(principal),
+		KafkaID:        types.StringValue(kafkaID),
+		ResourceType:   types.StringValue("TOPIC"),
+		ResourceName:   types.StringValue("orders"),
+		PatternType:    types.StringValue("LITERAL"),
+		OperationType:  types.StringValue("READ"),
+		PermissionType: types.StringValue("ALLOW"),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType}),
+		},
+	}
+}
+
+func testACLPlan(t *testing.T, r *aclResource, model aclModel) tfsdk.Plan {
+	plan := tfsdk.Plan{Schema: testACLSchema(t, r).Schema}
+	diags := plan.Set(context.Background(), &model)
+	assert.Empty(t, diags, "got unexpected diagnostics")
+
+	return plan
+}
+
+func TestACLCreate(t *testing.T) {
+	r, bindings := testACLResource(t, false)
+	s := testACLSchema(t, r).Schema
+
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)},
+	}
+	r.Create(context.Background(), resource.CreateRequest{Plan: testACLPlan(t, r, testACLModel("test-kafka-id", "jdoe"))}, &resp)
+	if !assert.Empty(t, resp.Diagnostics, "got unexpected diagnostics") {
+		return
+	}
+
+	var state aclModel
+	resp.State.Get(context.Background(), &state)
+	assert.Equal(t, "test-kafka-id/User:jdoe/TOPIC/orders/LITERAL/READ/ALLOW", state.ID.ValueString())
+
+	if assert.Len(t, *bindings, 1) {
+		binding := (*bindings)[0]
+		assert.Equal(t, PrincipalPrefix+"jdoe", binding.Principal)
+		assert.Equal(t, kafkainstanceclient.AclResourceType("TOPIC"), binding.ResourceType)
+		assert.Equal(t, "orders", binding.ResourceName)
+	}
+}
+
+func TestACLModifyPlanReferences(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  bool
+		kafkaID   string
+		wantError bool
+	}{
+		{
+			name:     "existing kafka instance",
+			validate: true,
+			kafkaID:  "test-kafka-id",
+		},
+		{
+			name:      "missing kafka instance",
+			validate:  true,
+			kafkaID:   "other-org-kafka",
+			wantError: true,
+		},
+		{
+			name:     "references not validated",
+			validate: false,
+			kafkaID:  "other-org-kafka",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := testACLResource(t, tt.validate)
+			s := testACLSchema(t, r).Schema
+
+			plan := testACLPlan(t, r, testACLModel(tt.kafkaID, "jdoe"))
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+				Plan:  plan,
+				State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)},
+			}, &resp)
+
+			if tt.wantError {
+				if assert.True(t, resp.Diagnostics.HasError(), "expected an error") {
+					assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `kafka_id: the Kafka instance "other-org-kafka"`)
+				}
+			} else {
+				assert.Empty(t, resp.Diagnostics, "got unexpected diagnostics")
+			}
+		})
+	}
+}
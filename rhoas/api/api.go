@@ -5,14 +5,31 @@ import (
 	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	svcacctmgmtclient "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/accountmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
 	"net/http"
+	"time"
 )
 
 type Factory interface {
 	KafkaMgmt() kafkamgmtclient.DefaultApi
 	ServiceAccountMgmt() svcacctmgmtclient.ServiceAccountsApi
+	AccountMgmt() accountmgmt.API
+	RegistryMgmt() registrymgmt.API
+	ConnectorMgmt() connectormgmt.API
+	SmartEventsMgmt() smarteventsmgmt.API
 	KafkaAdmin(ctx *context.Context, instanceID string) (*kafkainstanceclient.APIClient, *kafkamgmtclient.KafkaRequest, error)
+	RegistryInstance(ctx *context.Context, registryID string) (registryinstance.API, *registrymgmt.Registry, error)
 	HTTPClient() *http.Client
+	AuthURL() string
 	Localizer() localize.Localizer
+	PollInterval() time.Duration
+	PollMaxInterval() time.Duration
+	ValidateReferences() bool
+	KafkaExists(ctx context.Context, id string) (bool, error)
+	ServiceAccountExists(ctx context.Context, clientID string) (bool, error)
 }
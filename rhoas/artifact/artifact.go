@@ -0,0 +1,89 @@
+package artifact
+
+import (
+	"context"
+
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RegistryIDField        = "registry_id"
+	GroupIDField           = "group_id"
+	ArtifactIDField        = "artifact_id"
+	TypeField              = "type"
+	ContentField           = "content"
+	VersionField           = "version"
+	GlobalIDField          = "global_id"
+	ContentIDField         = "content_id"
+	ValidityRuleField      = "validity_rule"
+	CompatibilityRuleField = "compatibility_rule"
+
+	// the group of the artifacts which are not explicitly grouped
+	DefaultGroupID = "default"
+
+	// separates the registry id, the group id and the artifact id in the id of the resource
+	idSeparator = "/"
+)
+
+var ArtifactTypes = []string{"AVRO", "PROTOBUF", "JSON"}
+
+// artifactRuleFields maps the fields of the artifact rules to the type of the rule
+var artifactRuleFields = map[string]string{
+	ValidityRuleField:      registryinstance.RuleTypeValidity,
+	CompatibilityRuleField: registryinstance.RuleTypeCompatibility,
+}
+
+// setArtifactRule configures a rule of the artifact. An empty configuration
+// removes the rule, exists tells whether the artifact has the rule already
+func setArtifactRule(ctx context.Context, factory rhoasAPI.Factory, client registryinstance.API, groupID string, artifactID string, ruleType string, config string, exists bool) error {
+	switch {
+	case config == "" && exists:
+		resp, err := client.DeleteArtifactRule(ctx, groupID, artifactID, ruleType)
+		if resp != nil && utils.CheckNotFound(resp) {
+			return nil
+		}
+		return utils.GetAPIError(factory, resp, err)
+	case config == "":
+		return nil
+	case exists:
+		_, resp, err := client.UpdateArtifactRule(ctx, groupID, artifactID, registryinstance.Rule{Type: ruleType, Config: config})
+		return utils.GetAPIError(factory, resp, err)
+	default:
+		resp, err := client.CreateArtifactRule(ctx, groupID, artifactID, registryinstance.Rule{Type: ruleType, Config: config})
+		return utils.GetAPIError(factory, resp, err)
+	}
+}
+
+// getArtifactRules returns the configuration of the rules of the artifact by the
+// field of the rule, with an empty configuration for the rules which are not set
+func getArtifactRules(ctx context.Context, factory rhoasAPI.Factory, client registryinstance.API, groupID string, artifactID string) (map[string]string, error) {
+	ruleTypes, resp, err := client.ListArtifactRules(ctx, groupID, artifactID)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	configured := map[string]bool{}
+	for _, ruleType := range ruleTypes {
+		configured[ruleType] = true
+	}
+
+	rules := map[string]string{}
+	for field, ruleType := range artifactRuleFields {
+		rules[field] = ""
+
+		if !configured[ruleType] {
+			continue
+		}
+
+		rule, resp, err := client.GetArtifactRule(ctx, groupID, artifactID, ruleType)
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+
+		rules[field] = rule.Config
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,336 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+// nolint:funlen
+func ResourceRegistryArtifact(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_registry_artifact` manages a schema artifact in a Service Registry instance in Red Hat OpenShift Service Registry. Changing the content of the artifact adds a new version, which is checked against the rules of the artifact and of the registry.",
+		CreateContext: registryArtifactCreate,
+		ReadContext:   registryArtifactRead,
+		UpdateContext: registryArtifactUpdate,
+		DeleteContext: registryArtifactDelete,
+		CustomizeDiff: registryArtifactCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: registryArtifactImport,
+		},
+		Schema: map[string]*schema.Schema{
+			RegistryIDField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.registryID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			GroupIDField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.groupID"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DefaultGroupID,
+				ForceNew:    true,
+			},
+			ArtifactIDField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.artifactID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			TypeField: {
+				Description:  localizer.MustLocalize("artifact.resource.field.description.type"),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(ArtifactTypes, false),
+			},
+			ContentField: {
+				Description:      localizer.MustLocalize("artifact.resource.field.description.content"),
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: utils.SuppressEquivalentJSON,
+			},
+			ValidityRuleField: {
+				Description:  localizer.MustLocalize("artifact.resource.field.description.validityRule"),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(registryinstance.RuleConfigs[registryinstance.RuleTypeValidity], false),
+			},
+			CompatibilityRuleField: {
+				Description:  localizer.MustLocalize("artifact.resource.field.description.compatibilityRule"),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(registryinstance.RuleConfigs[registryinstance.RuleTypeCompatibility], false),
+			},
+			VersionField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.version"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			GlobalIDField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.globalID"),
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			ContentIDField: {
+				Description: localizer.MustLocalize("artifact.resource.field.description.contentID"),
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// getArtifactID returns the registry, group and artifact ids of the resource
+func getArtifactID(factory rhoasAPI.Factory, d *schema.ResourceData) (string, string, string, error) {
+	ids := make([]string, 3)
+
+	for i, field := range []string{RegistryIDField, GroupIDField, ArtifactIDField} {
+		id, ok := d.Get(field).(string)
+		if !ok {
+			return "", "", "", factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field))
+		}
+
+		ids[i] = id
+	}
+
+	return ids[0], ids[1], ids[2], nil
+}
+
+func registryArtifactCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, groupID, artifactID, err := getArtifactID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	artifactType, ok := d.Get(TypeField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TypeField)))
+	}
+
+	content, ok := d.Get(ContentField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ContentField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, resp, err := registryAPI.CreateArtifact(ctx, groupID, artifactID, artifactType, content)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(strings.Join([]string{registryID, groupID, artifactID}, idSeparator))
+
+	for field, ruleType := range artifactRuleFields {
+		config, ok := d.Get(field).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field)))
+		}
+
+		if err = setArtifactRule(ctx, factory, registryAPI, groupID, artifactID, ruleType, config, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return registryArtifactRead(ctx, d, m)
+}
+
+func registryArtifactRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, groupID, artifactID, err := getArtifactID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metaData, resp, err := registryAPI.GetArtifactMetaData(ctx, groupID, artifactID)
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the artifact was deleted outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	content, resp, err := registryAPI.GetLatestArtifact(ctx, groupID, artifactID)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	rules, err := getArtifactRules(ctx, factory, registryAPI, groupID, artifactID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields := map[string]interface{}{
+		TypeField:      metaData.Type,
+		ContentField:   content,
+		VersionField:   metaData.Version,
+		GlobalIDField:  metaData.GlobalID,
+		ContentIDField: metaData.ContentID,
+	}
+	for field, config := range rules {
+		fields[field] = config
+	}
+
+	for field, value := range fields {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func registryArtifactUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, groupID, artifactID, err := getArtifactID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// keep the previous content in the state when the new content is rejected
+	d.Partial(true)
+
+	// the rules are changed first so the new content is checked against them
+	for field, ruleType := range artifactRuleFields {
+		if !d.HasChange(field) {
+			continue
+		}
+
+		old, config := d.GetChange(field)
+		if err = setArtifactRule(ctx, factory, registryAPI, groupID, artifactID, ruleType, config.(string), old.(string) != ""); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange(ContentField) {
+		artifactType, ok := d.Get(TypeField).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TypeField)))
+		}
+
+		content, ok := d.Get(ContentField).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ContentField)))
+		}
+
+		_, resp, err := registryAPI.CreateArtifactVersion(ctx, groupID, artifactID, artifactType, content)
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return diag.FromErr(apiErr)
+		}
+	}
+
+	d.Partial(false)
+
+	return registryArtifactRead(ctx, d, m)
+}
+
+func registryArtifactDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, groupID, artifactID, err := getArtifactID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.DeleteArtifact(ctx, groupID, artifactID)
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// registryArtifactCustomizeDiff marks the version of the artifact as changing
+// whenever the content changes, as every new content is a new version
+func registryArtifactCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange(ContentField) {
+		return nil
+	}
+
+	// the diff of a customize diff function ignores suppressed changes, so a
+	// reformatted json document has to be checked for here as well
+	old, content := d.GetChange(ContentField)
+	if utils.SuppressEquivalentJSON(ContentField, old.(string), content.(string), nil) {
+		return nil
+	}
+
+	for _, field := range []string{VersionField, GlobalIDField, ContentIDField} {
+		if err := d.SetNewComputed(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registryArtifactImport imports an artifact from an id of the form <registry_id>/<group_id>/<artifact_id>
+func registryArtifactImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), idSeparator, 3)
+	if len(ids) != 3 || ids[0] == "" || ids[1] == "" || ids[2] == "" {
+		return nil, fmt.Errorf("unexpected id %q, expected <registry_id>%s<group_id>%s<artifact_id>", d.Id(), idSeparator, idSeparator)
+	}
+
+	for i, field := range []string{RegistryIDField, GroupIDField, ArtifactIDField} {
+		if err := d.Set(field, ids[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,257 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeArtifact struct {
+	artifactType string
+	versions     []string
+	rules        map[string]string
+}
+
+// fakeRegistry serves the artifacts of the api of a registry. Content
+// containing "incompatible" is rejected as violating the rules of the artifact
+type fakeRegistry struct {
+	t         *testing.T
+	artifacts map[string]*fakeArtifact
+}
+
+func (f *fakeRegistry) metaData(groupID string, artifactID string) registryinstance.ArtifactMetaData {
+	artifact := f.artifacts[groupID+"/"+artifactID]
+	return registryinstance.ArtifactMetaData{
+		GroupID:   groupID,
+		ID:        artifactID,
+		Type:      artifact.artifactType,
+		Version:   strconv.Itoa(len(artifact.versions)),
+		GlobalID:  int64(100 + len(artifact.versions)),
+		ContentID: int64(200 + len(artifact.versions)),
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// /groups/<group>/artifacts[/<artifact>[/<subresource>...]]
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/groups/"), "/")
+	if !strings.HasPrefix(r.URL.Path, "/groups/") || len(parts) < 2 || parts[1] != "artifacts" {
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	groupID := parts[0]
+
+	if len(parts) == 2 && r.Method == http.MethodPost {
+		artifactID := r.Header.Get("X-Registry-ArtifactId")
+		f.artifacts[groupID+"/"+artifactID] = &fakeArtifact{artifactType: r.Header.Get("X-Registry-ArtifactType"), versions: []string{string(body)}, rules: map[string]string{}}
+		fakeapi.WriteJSON(w, http.StatusOK, f.metaData(groupID, artifactID))
+		return
+	}
+
+	artifactID := parts[2]
+	artifact, ok := f.artifacts[groupID+"/"+artifactID]
+	if !ok {
+		fakeapi.WriteJSON(w, http.StatusNotFound, map[string]interface{}{"error_code": 404, "name": "ArtifactNotFoundException", "message": "No artifact with ID '" + artifactID + "'"})
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(artifact.versions[len(artifact.versions)-1]))
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		delete(f.artifacts, groupID+"/"+artifactID)
+		w.WriteHeader(http.StatusNoContent)
+	case parts[3] == "meta":
+		fakeapi.WriteJSON(w, http.StatusOK, f.metaData(groupID, artifactID))
+	case parts[3] == "versions":
+		if strings.Contains(string(body), "incompatible") {
+			fakeapi.WriteJSON(w, http.StatusConflict, map[string]interface{}{
+				"error_code": 409,
+				"name":       "RuleViolationException",
+				"message":    "Incompatible artifact: " + artifactID,
+				"causes":     []map[string]string{{"description": "Field removed without a default", "context": "/fields/1"}},
+			})
+			return
+		}
+		artifact.versions = append(artifact.versions, string(body))
+		fakeapi.WriteJSON(w, http.StatusOK, f.metaData(groupID, artifactID))
+	case parts[3] == "rules" && len(parts) == 4 && r.Method == http.MethodGet:
+		ruleTypes := make([]string, 0)
+		for ruleType := range artifact.rules {
+			ruleTypes = append(ruleTypes, ruleType)
+		}
+		fakeapi.WriteJSON(w, http.StatusOK, ruleTypes)
+	case parts[3] == "rules" && r.Method == http.MethodDelete:
+		delete(artifact.rules, parts[4])
+		w.WriteHeader(http.StatusNoContent)
+	case parts[3] == "rules" && len(parts) == 5 && r.Method == http.MethodGet:
+		fakeapi.WriteJSON(w, http.StatusOK, registryinstance.Rule{Type: parts[4], Config: artifact.rules[parts[4]]})
+	case parts[3] == "rules":
+		var rule registryinstance.Rule
+		_ = json.Unmarshal(body, &rule)
+		artifact.rules[rule.Type] = rule.Config
+		fakeapi.WriteJSON(w, http.StatusOK, rule)
+	}
+}
+
+// testRegistryFactory returns a factory whose registry is a local fake, along
+// with the fake to inspect its artifacts
+func testRegistryFactory(t *testing.T) (rhoasAPI.Factory, *fakeRegistry) {
+	fake := &fakeRegistry{t: t, artifacts: map[string]*fakeArtifact{}}
+
+	return fakeapi.RegistryFactory(t, fake.ServeHTTP), fake
+}
+
+const testSchema = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+
+func TestResourceRegistryArtifactLifecycle(t *testing.T) {
+	factory, fake := testRegistryFactory(t)
+
+	r := ResourceRegistryArtifact(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		RegistryIDField:        "test-registry",
+		ArtifactIDField:        "orders-value",
+		TypeField:              "AVRO",
+		ContentField:           testSchema,
+		CompatibilityRuleField: "BACKWARD",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "test-registry/default/orders-value", d.Id())
+	assert.Equal(t, "1", d.Get(VersionField))
+	assert.Equal(t, 101, d.Get(GlobalIDField))
+	assert.Equal(t, 201, d.Get(ContentIDField))
+	assert.Equal(t, testSchema, d.Get(ContentField))
+	assert.Equal(t, "BACKWARD", d.Get(CompatibilityRuleField))
+	assert.Equal(t, "", d.Get(ValidityRuleField))
+	assert.Equal(t, map[string]string{"COMPATIBILITY": "BACKWARD"}, fake.artifacts["default/orders-value"].rules)
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.artifacts)
+}
+
+func TestResourceRegistryArtifactUpdate(t *testing.T) {
+	factory, fake := testRegistryFactory(t)
+	fake.artifacts["default/orders-value"] = &fakeArtifact{artifactType: "AVRO", versions: []string{testSchema}, rules: map[string]string{"COMPATIBILITY": "BACKWARD"}}
+
+	r := ResourceRegistryArtifact(factory.Localizer())
+	state := &terraform.InstanceState{
+		ID: "test-registry/default/orders-value",
+		Attributes: map[string]string{
+			RegistryIDField:        "test-registry",
+			GroupIDField:           DefaultGroupID,
+			ArtifactIDField:        "orders-value",
+			TypeField:              "AVRO",
+			ContentField:           testSchema,
+			CompatibilityRuleField: "BACKWARD",
+			ValidityRuleField:      "",
+			VersionField:           "1",
+			GlobalIDField:          "101",
+			ContentIDField:         "201",
+		},
+	}
+	config := map[string]interface{}{
+		RegistryIDField: "test-registry",
+		ArtifactIDField: "orders-value",
+		TypeField:       "AVRO",
+	}
+
+	t.Run("new content adds a version", func(t *testing.T) {
+		newSchema := strings.Replace(testSchema, `"string"}]`, `"string"}, {"name": "total", "type": "int", "default": 0}]`, 1)
+		config[ContentField] = newSchema
+		config[ValidityRuleField] = "FULL"
+
+		d := testUpdateResourceData(t, r, state, config)
+		assert.True(t, d.HasChange(VersionField), "expected the version to change with the content")
+
+		diags := r.UpdateContext(context.Background(), d, factory)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "2", d.Get(VersionField))
+		assert.Equal(t, 102, d.Get(GlobalIDField))
+		assert.Equal(t, newSchema, d.Get(ContentField))
+		assert.Equal(t, map[string]string{"VALIDITY": "FULL"}, fake.artifacts["default/orders-value"].rules)
+	})
+
+	t.Run("reformatted content is no change", func(t *testing.T) {
+		config[ContentField] = strings.ReplaceAll(testSchema, " ", "")
+		config[CompatibilityRuleField] = "BACKWARD"
+		delete(config, ValidityRuleField)
+
+		d := testUpdateResourceData(t, r, state, config)
+		assert.False(t, d.HasChange(ContentField))
+		assert.False(t, d.HasChange(VersionField))
+	})
+
+	t.Run("rule violations are reported", func(t *testing.T) {
+		config[ContentField] = `{"incompatible": true}`
+
+		diags := r.UpdateContext(context.Background(), testUpdateResourceData(t, r, state, config), factory)
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "rejected by the rules")
+		assert.Contains(t, diags[0].Summary, "Field removed without a default (/fields/1)")
+	})
+}
+
+// testUpdateResourceData returns the resource data to update the resource in
+// the given state to the given configuration
+func testUpdateResourceData(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestRegistryArtifactImport(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceRegistryArtifact(localizer)
+
+	tests := []struct {
+		name           string
+		id             string
+		wantArtifactID string
+		wantErr        bool
+	}{
+		{name: "artifact id", id: "test-registry/orders/orders-value", wantArtifactID: "orders-value"},
+		{name: "artifact id with separator", id: "test-registry/orders/com/example/Order", wantArtifactID: "com/example/Order"},
+		{name: "missing group", id: "test-registry/orders-value", wantErr: true},
+		{name: "empty group", id: "test-registry//orders-value", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := r.TestResourceData()
+			d.SetId(tt.id) // nolint:scopelint
+
+			_, err := registryArtifactImport(context.Background(), d, nil)
+			assert.Equal(t, tt.wantErr, err != nil, err) // nolint:scopelint
+			if !tt.wantErr {                             // nolint:scopelint
+				assert.Equal(t, tt.wantArtifactID, d.Get(ArtifactIDField)) // nolint:scopelint
+				assert.Equal(t, "test-registry", d.Get(RegistryIDField))
+			}
+		})
+	}
+}
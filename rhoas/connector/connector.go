@@ -0,0 +1,114 @@
+package connector
+
+import (
+	"context"
+	"time"
+
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	IDField              = "id"
+	NameField            = "name"
+	NamespaceIDField     = "namespace_id"
+	ConnectorTypeIDField = "connector_type_id"
+	ChannelField         = "channel"
+	KafkaIDField         = "kafka_id"
+	ClientIDField        = "client_id"
+	ClientSecretField    = "client_secret"
+	ConnectorField       = "connector"
+	DesiredStateField    = "desired_state"
+	ClusterIDField       = "cluster_id"
+	AnnotationsField     = "annotations"
+	StatusField          = "status"
+	ExpirationField      = "expiration"
+	OwnerField           = "owner"
+	CreatedAtField       = "created_at"
+
+	DefaultChannel = "stable"
+
+	StateReady        = "ready"
+	StateStopped      = "stopped"
+	StateFailed       = "failed"
+	StateDisconnected = "disconnected"
+	StateDeleting     = "deleting"
+	StateDeleted      = "deleted"
+)
+
+// connectorStates are the states a connector goes through on its way to its
+// desired state, or when it is deleted
+var connectorStates = []string{
+	"assigning",
+	"assigned",
+	"updating",
+	"provisioning",
+	"deprovisioning",
+	StateReady,
+	StateStopped,
+	StateDeleting,
+}
+
+// DesiredStates are the states a connector can be asked to be in
+var DesiredStates = []string{StateReady, StateStopped}
+
+// waitForConnectorState waits until the connector with the given id reaches
+// the given state, failing when the connector fails
+func waitForConnectorState(ctx context.Context, factory rhoasAPI.Factory, id string, state string, timeout time.Duration) (*connectormgmt.Connector, error) {
+	pending := make([]string, 0, len(connectorStates))
+	for _, s := range connectorStates {
+		if s != state {
+			pending = append(pending, s)
+		}
+	}
+
+	stateConf := utils.NewStateChangeConf(
+		pending,
+		[]string{
+			state,
+		},
+		func() (interface{}, string, error) {
+			connector, resp, err := factory.ConnectorMgmt().GetConnector(ctx, id)
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			if connector.Status.State == StateFailed {
+				return connector, connector.Status.State, factory.Localizer().MustLocalizeError("connector.errors.failed", localize.NewEntry("Name", connector.Name), localize.NewEntry("Error", connector.Status.Error))
+			}
+
+			return connector, connector.Status.State, nil
+		},
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	data, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, _ := data.(connectormgmt.Connector)
+
+	return &connector, nil
+}
+
+// getKafkaConnection returns the connection of a connector to the Kafka instance with the given id
+func getKafkaConnection(ctx context.Context, factory rhoasAPI.Factory, kafkaID string) (connectormgmt.KafkaConnection, error) {
+	kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, kafkaID).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return connectormgmt.KafkaConnection{}, apiErr
+	}
+
+	if kafka.GetBootstrapServerHost() == "" {
+		return connectormgmt.KafkaConnection{}, factory.Localizer().MustLocalizeError("connector.errors.kafkaNotReady", localize.NewEntry("Name", kafka.GetName()))
+	}
+
+	return connectormgmt.KafkaConnection{
+		ID:  kafkaID,
+		URL: kafka.GetBootstrapServerHost(),
+	}, nil
+}
@@ -0,0 +1,182 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	ConnectorTypesField = "connector_types"
+	LabelField          = "label"
+	VersionField        = "version"
+	DescriptionField    = "description"
+	LabelsField         = "labels"
+	ChannelsField       = "channels"
+	SchemaField         = "schema"
+)
+
+// nolint:funlen
+func DataSourceConnectorTypes(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_connector_types` provides a list of the connector types of the catalog of Red Hat OpenShift Connectors, along with the JSON schema of the configuration of their connectors.",
+		ReadContext: dataSourceConnectorTypesRead,
+		Schema: map[string]*schema.Schema{
+			NameField: {
+				Description: localizer.MustLocalize("connector.types.field.description.filterName"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			LabelField: {
+				Description: localizer.MustLocalize("connector.types.field.description.filterLabel"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			ChannelField: {
+				Description: localizer.MustLocalize("connector.types.field.description.filterChannel"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			ConnectorTypesField: {
+				Description: localizer.MustLocalize("connector.types.field.description.connectorTypes"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Description: localizer.MustLocalize("connector.types.field.description.id"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						NameField: {
+							Description: localizer.MustLocalize("connector.types.field.description.name"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						VersionField: {
+							Description: localizer.MustLocalize("connector.types.field.description.version"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						DescriptionField: {
+							Description: localizer.MustLocalize("connector.types.field.description.description"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						LabelsField: {
+							Description: localizer.MustLocalize("connector.types.field.description.labels"),
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						ChannelsField: {
+							Description: localizer.MustLocalize("connector.types.field.description.channels"),
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						SchemaField: {
+							Description: localizer.MustLocalize("connector.types.field.description.schema"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConnectorTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	filters := map[string]string{}
+	for _, field := range []string{NameField, LabelField, ChannelField} {
+		value, ok := d.Get(field).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field)))
+		}
+
+		filters[field] = value
+	}
+
+	connectorTypes, resp, err := factory.ConnectorMgmt().ListConnectorTypes(ctx, buildConnectorTypesSearchQuery(filters[NameField], filters[LabelField], filters[ChannelField]))
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = d.Set(ConnectorTypesField, flattenConnectorTypes(connectorTypes)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// use the current timestamp for a list request to force a refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// buildConnectorTypesSearchQuery converts the filters into a search expression
+// for the connector type list api, using "like" when the name contains a "%"
+// wildcard
+func buildConnectorTypesSearchQuery(name string, label string, channel string) string {
+	quote := func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+
+	conditions := make([]string, 0, 3)
+
+	if name != "" {
+		operator := "="
+		if strings.Contains(name, "%") {
+			operator = "like"
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", NameField, operator, quote(name)))
+	}
+
+	if label != "" {
+		conditions = append(conditions, fmt.Sprintf("%s = %s", LabelField, quote(label)))
+	}
+
+	if channel != "" {
+		conditions = append(conditions, fmt.Sprintf("%s = %s", ChannelField, quote(channel)))
+	}
+
+	return strings.Join(conditions, " and ")
+}
+
+func flattenConnectorTypes(connectorTypes []connectormgmt.ConnectorType) []interface{} {
+	cts := make([]interface{}, len(connectorTypes))
+
+	for i, connectorType := range connectorTypes {
+		cts[i] = map[string]interface{}{
+			IDField:          connectorType.ID,
+			NameField:        connectorType.Name,
+			VersionField:     connectorType.Version,
+			DescriptionField: connectorType.Description,
+			LabelsField:      connectorType.Labels,
+			ChannelsField:    connectorType.Channels,
+			SchemaField:      string(connectorType.Schema),
+		}
+	}
+
+	return cts
+}
@@ -0,0 +1,373 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+// nolint:funlen
+func ResourceConnector(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_connector` manages a connector in Red Hat OpenShift Connectors, which moves data between a Kafka instance in Red Hat OpenShift Streams for Apache Kafka and another system.",
+		CreateContext: connectorCreate,
+		ReadContext:   connectorRead,
+		UpdateContext: connectorUpdate,
+		DeleteContext: connectorDelete,
+		CustomizeDiff: connectorCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.id"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			NameField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.name"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			NamespaceIDField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.namespaceID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			ConnectorTypeIDField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.connectorTypeID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			ChannelField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.channel"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DefaultChannel,
+				ForceNew:    true,
+			},
+			KafkaIDField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			ClientIDField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.clientID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			ClientSecretField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.clientSecret"),
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			ConnectorField: {
+				Description:      localizer.MustLocalize("connector.resource.field.description.connector"),
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: utils.SuppressEquivalentJSON,
+			},
+			DesiredStateField: {
+				Description:  localizer.MustLocalize("connector.resource.field.description.desiredState"),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      StateReady,
+				ValidateFunc: validation.StringInSlice(DesiredStates, false),
+			},
+			StatusField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.status"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OwnerField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.owner"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			CreatedAtField: {
+				Description: localizer.MustLocalize("connector.resource.field.description.createdAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// mapResourceDataToConnectorRequest builds the request to create the connector of the resource
+func mapResourceDataToConnectorRequest(factory rhoasAPI.Factory, d *schema.ResourceData) (connectormgmt.ConnectorRequest, error) {
+	fields := map[string]string{}
+	for _, field := range []string{NameField, NamespaceIDField, ConnectorTypeIDField, ChannelField, ClientIDField, ClientSecretField, ConnectorField, DesiredStateField} {
+		value, ok := d.Get(field).(string)
+		if !ok {
+			return connectormgmt.ConnectorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field))
+		}
+
+		fields[field] = value
+	}
+
+	return connectormgmt.ConnectorRequest{
+		Name:            fields[NameField],
+		NamespaceID:     fields[NamespaceIDField],
+		ConnectorTypeID: fields[ConnectorTypeIDField],
+		Channel:         fields[ChannelField],
+		DesiredState:    fields[DesiredStateField],
+		ServiceAccount: connectormgmt.ServiceAccount{
+			ClientID:     fields[ClientIDField],
+			ClientSecret: fields[ClientSecretField],
+		},
+		Connector: json.RawMessage(fields[ConnectorField]),
+	}, nil
+}
+
+func connectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	request, err := mapResourceDataToConnectorRequest(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	request.Kafka, err = getKafkaConnection(ctx, factory, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	connector, resp, err := factory.ConnectorMgmt().CreateConnector(ctx, request)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(connector.ID)
+
+	if _, err = waitForConnectorState(ctx, factory, connector.ID, request.DesiredState, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return connectorRead(ctx, d, m)
+}
+
+func connectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	connector, resp, err := factory.ConnectorMgmt().GetConnector(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the connector was deleted outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	// the connector spec and the client secret are not read back, the api
+	// redacts the secrets of the spec and never returns the client secret
+	fields := map[string]interface{}{
+		NameField:            connector.Name,
+		NamespaceIDField:     connector.NamespaceID,
+		ConnectorTypeIDField: connector.ConnectorTypeID,
+		ChannelField:         connector.Channel,
+		KafkaIDField:         connector.Kafka.ID,
+		ClientIDField:        connector.ServiceAccount.ClientID,
+		DesiredStateField:    connector.DesiredState,
+		StatusField:          connector.Status.State,
+		OwnerField:           connector.Owner,
+		CreatedAtField:       connector.CreatedAt,
+	}
+
+	for field, value := range fields {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func connectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	request, err := mapResourceDataToConnectorRequest(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the changed fields are patched, so a stopped connector is not
+	// restarted by a change of its name for example
+	patch := map[string]interface{}{}
+
+	if d.HasChange(NameField) {
+		patch["name"] = request.Name
+	}
+
+	if d.HasChange(KafkaIDField) {
+		kafkaID, ok := d.Get(KafkaIDField).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+		}
+
+		if patch["kafka"], err = getKafkaConnection(ctx, factory, kafkaID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges(ClientIDField, ClientSecretField) {
+		patch["service_account"] = request.ServiceAccount
+	}
+
+	if d.HasChange(ConnectorField) {
+		// the patch only changes the members it holds, so it sets the members
+		// removed from the configuration of the connector to null. The previous
+		// configuration is not known after an import, as it is not read back,
+		// so the whole configuration is sent instead
+		oldConnector, _ := d.GetChange(ConnectorField)
+		if oldSpec, _ := oldConnector.(string); json.Valid([]byte(oldSpec)) {
+			if patch["connector"], err = utils.JSONMergePatch(oldSpec, string(request.Connector)); err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			patch["connector"] = request.Connector
+		}
+	}
+
+	if d.HasChange(DesiredStateField) {
+		patch["desired_state"] = request.DesiredState
+	}
+
+	if len(patch) > 0 {
+		_, resp, err := factory.ConnectorMgmt().PatchConnector(ctx, d.Id(), patch)
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return diag.FromErr(apiErr)
+		}
+
+		if _, err = waitForConnectorState(ctx, factory, d.Id(), request.DesiredState, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return connectorRead(ctx, d, m)
+}
+
+// connectorCustomizeDiff fails the plan when the connector configuration does
+// not match the json schema of its connector type, rather than the apply
+func connectorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges(ConnectorField, ConnectorTypeIDField) || !d.NewValueKnown(ConnectorField) || !d.NewValueKnown(ConnectorTypeIDField) {
+		return nil
+	}
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return nil
+	}
+
+	connectorTypeID, _ := d.Get(ConnectorTypeIDField).(string)
+	spec, _ := d.Get(ConnectorField).(string)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(spec), &value); err != nil {
+		// the configuration is validated as json by its schema
+		return nil
+	}
+
+	connectorType, resp, err := factory.ConnectorMgmt().GetConnectorType(ctx, connectorTypeID)
+	if resp != nil && utils.CheckNotFound(resp) {
+		return fmt.Errorf("%s: the connector type %q does not exist", ConnectorTypeIDField, connectorTypeID)
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return apiErr
+	}
+
+	var typeSchema map[string]interface{}
+	if err = json.Unmarshal(connectorType.Schema, &typeSchema); err != nil {
+		// a connector type without a usable schema is left to the api
+		return nil
+	}
+
+	if errs := specErrors(typeSchema, value, ConnectorField); len(errs) > 0 {
+		return fmt.Errorf("the %s configuration does not match the schema of the %s connector type: %s", ConnectorField, connectorTypeID, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func connectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	resp, err := factory.ConnectorMgmt().DeleteConnector(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	deleteStateConf := utils.NewStateChangeConf(
+		append([]string{StateFailed}, connectorStates...),
+		[]string{
+			StateDeleted,
+		},
+		func() (interface{}, string, error) {
+			connector, resp, err := factory.ConnectorMgmt().GetConnector(ctx, d.Id())
+			if resp != nil && utils.CheckNotFound(resp) {
+				return connector, StateDeleted, nil
+			}
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			return connector, connector.Status.State, nil
+		},
+		d.Timeout(schema.TimeoutDelete),
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	if _, err = deleteStateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,227 @@
+package connector
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+func ResourceConnectorNamespace(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_connector_namespace` manages a namespace connectors are deployed to in Red Hat OpenShift Connectors. Without a cluster an evaluation namespace is created, which expires after a while.",
+		CreateContext: connectorNamespaceCreate,
+		ReadContext:   connectorNamespaceRead,
+		DeleteContext: connectorNamespaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.id"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			NameField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.name"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			ClusterIDField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.clusterID"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			AnnotationsField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.annotations"),
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			StatusField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.status"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			ExpirationField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.expiration"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OwnerField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.owner"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			CreatedAtField: {
+				Description: localizer.MustLocalize("connector.namespace.field.description.createdAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func connectorNamespaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField)))
+	}
+
+	clusterID, ok := d.Get(ClusterIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ClusterIDField)))
+	}
+
+	rawAnnotations, ok := d.Get(AnnotationsField).(map[string]interface{})
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", AnnotationsField)))
+	}
+
+	annotations := make(map[string]string, len(rawAnnotations))
+	for key, value := range rawAnnotations {
+		annotations[key], _ = value.(string)
+	}
+
+	namespace, resp, err := factory.ConnectorMgmt().CreateConnectorNamespace(ctx, connectormgmt.ConnectorNamespaceRequest{
+		Name:        name,
+		ClusterID:   clusterID,
+		Annotations: annotations,
+	})
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(namespace.ID)
+
+	readyStateConf := utils.NewStateChangeConf(
+		[]string{
+			StateDisconnected,
+		},
+		[]string{
+			StateReady,
+		},
+		func() (interface{}, string, error) {
+			namespace, resp, err := factory.ConnectorMgmt().GetConnectorNamespace(ctx, d.Id())
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			return namespace, namespace.Status.State, nil
+		},
+		d.Timeout(schema.TimeoutCreate),
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	if _, err = readyStateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return connectorNamespaceRead(ctx, d, m)
+}
+
+func connectorNamespaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	namespace, resp, err := factory.ConnectorMgmt().GetConnectorNamespace(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the namespace was deleted outside of terraform or has expired
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	fields := map[string]interface{}{
+		NameField:        namespace.Name,
+		ClusterIDField:   namespace.ClusterID,
+		AnnotationsField: namespace.Annotations,
+		StatusField:      namespace.Status.State,
+		ExpirationField:  namespace.Expiration,
+		OwnerField:       namespace.Owner,
+		CreatedAtField:   namespace.CreatedAt,
+	}
+
+	for field, value := range fields {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func connectorNamespaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	resp, err := factory.ConnectorMgmt().DeleteConnectorNamespace(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	deleteStateConf := utils.NewStateChangeConf(
+		[]string{
+			StateReady, StateDisconnected, StateDeleting,
+		},
+		[]string{
+			StateDeleted,
+		},
+		func() (interface{}, string, error) {
+			namespace, resp, err := factory.ConnectorMgmt().GetConnectorNamespace(ctx, d.Id())
+			if resp != nil && utils.CheckNotFound(resp) {
+				return namespace, StateDeleted, nil
+			}
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			return namespace, namespace.Status.State, nil
+		},
+		d.Timeout(schema.TimeoutDelete),
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	if _, err = deleteStateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,448 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConnectorMgmt is an in memory connector management api. Every read of a
+// connector or namespace advances it to its next state, connectors then settle
+// in their desired state. Deleted ones are gone after the first read
+type fakeConnectorMgmt struct {
+	namespaces map[string]*connectormgmt.ConnectorNamespace
+	connectors map[string]*connectormgmt.Connector
+	states     map[string][]string
+	types      []connectormgmt.ConnectorType
+	patches    []map[string]interface{}
+	search     string
+	evaluation bool
+}
+
+func (f *fakeConnectorMgmt) nextState(name string, settled string) string {
+	states := f.states[name]
+	if len(states) == 0 {
+		return settled
+	}
+
+	f.states[name] = states[1:]
+	return states[0]
+}
+
+func (f *fakeConnectorMgmt) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const (
+		namespacesPath = "/api/connector_mgmt/v1/kafka_connector_namespaces"
+		connectorsPath = "/api/connector_mgmt/v1/kafka_connectors"
+	)
+
+	writeJSON := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	notFound := map[string]string{"code": "CONNECTOR-MGMT-7", "reason": "not found"}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/kafkas_mgmt/"):
+		kafka := kafkamgmtclient.NewKafkaRequest("test-kafka-id", "Kafka", "", true, true)
+		kafka.SetName("test-kafka")
+		kafka.SetStatus("ready")
+		kafka.SetBootstrapServerHost("test-kafka.example.com:443")
+		writeJSON(http.StatusOK, kafka)
+	case r.URL.Path == "/api/connector_mgmt/v1/kafka_connector_types":
+		f.search = r.URL.Query().Get("search")
+		writeJSON(http.StatusOK, connectormgmt.ConnectorTypeList{Page: 1, Size: len(f.types), Total: len(f.types), Items: f.types})
+	case strings.HasPrefix(r.URL.Path, "/api/connector_mgmt/v1/kafka_connector_types/"):
+		for _, connectorType := range f.types {
+			if r.URL.Path == "/api/connector_mgmt/v1/kafka_connector_types/"+connectorType.ID {
+				writeJSON(http.StatusOK, connectorType)
+				return
+			}
+		}
+		writeJSON(http.StatusNotFound, notFound)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, namespacesPath):
+		var request connectormgmt.ConnectorNamespaceRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		f.evaluation = r.URL.Path == namespacesPath+"/eval"
+		namespace := &connectormgmt.ConnectorNamespace{ID: "namespace-" + request.Name, Name: request.Name, ClusterID: request.ClusterID, Annotations: request.Annotations}
+		namespace.Status.State = StateDisconnected
+		f.namespaces[namespace.ID] = namespace
+		writeJSON(http.StatusAccepted, namespace)
+	case strings.HasPrefix(r.URL.Path, namespacesPath+"/"):
+		namespace, ok := f.namespaces[r.URL.Path[len(namespacesPath)+1:]]
+		if !ok {
+			writeJSON(http.StatusNotFound, notFound)
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			namespace.Status.State = StateDeleting
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if namespace.Status.State == StateDeleting {
+			delete(f.namespaces, namespace.ID)
+		} else {
+			namespace.Status.State = f.nextState(namespace.Name, namespace.Status.State)
+		}
+		writeJSON(http.StatusOK, namespace)
+	case r.Method == http.MethodPost && r.URL.Path == connectorsPath:
+		var request connectormgmt.ConnectorRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		request.ServiceAccount.ClientSecret = ""
+		connector := &connectormgmt.Connector{
+			ID:              "connector-" + request.Name,
+			Name:            request.Name,
+			ConnectorTypeID: request.ConnectorTypeID,
+			NamespaceID:     request.NamespaceID,
+			Channel:         request.Channel,
+			DesiredState:    request.DesiredState,
+			Kafka:           request.Kafka,
+			ServiceAccount:  request.ServiceAccount,
+			Connector:       request.Connector,
+		}
+		connector.Status.State = "assigning"
+		f.connectors[connector.ID] = connector
+		writeJSON(http.StatusAccepted, connector)
+	case strings.HasPrefix(r.URL.Path, connectorsPath+"/"):
+		connector, ok := f.connectors[r.URL.Path[len(connectorsPath)+1:]]
+		if !ok {
+			writeJSON(http.StatusNotFound, notFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodDelete:
+			connector.Status.State = StateDeleting
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPatch:
+			var patch map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			f.patches = append(f.patches, patch)
+			if desiredState, ok := patch["desired_state"].(string); ok {
+				connector.DesiredState = desiredState
+			}
+			if name, ok := patch["name"].(string); ok {
+				connector.Name = name
+			}
+			connector.Status.State = "updating"
+			writeJSON(http.StatusAccepted, connector)
+			return
+		}
+
+		if connector.Status.State == StateDeleting {
+			delete(f.connectors, connector.ID)
+		} else {
+			connector.Status.State = f.nextState(connector.Name, connector.DesiredState)
+			if connector.Status.State == StateFailed {
+				connector.Status.Error = "the connector could not connect"
+			}
+		}
+		writeJSON(http.StatusOK, connector)
+	default:
+		writeJSON(http.StatusNotFound, notFound)
+	}
+}
+
+// testConnectorFactory returns a factory whose kafka and connector management
+// apis are a local fake, along with the fake to inspect its connectors
+func testConnectorFactory(t *testing.T, states map[string][]string) (rhoasAPI.Factory, *fakeConnectorMgmt) {
+	localizer, _ := goi18n.New(nil)
+
+	fake := &fakeConnectorMgmt{
+		namespaces: map[string]*connectormgmt.ConnectorNamespace{},
+		connectors: map[string]*connectormgmt.Connector{},
+		states:     states,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	return factories.NewDefaultFactory(factories.Options{
+		KafkaClient:         kafkaClient,
+		ConnectorMgmtClient: connectormgmt.NewAPIClient(server.Client(), server.URL),
+		HTTPClient:          server.Client(),
+		Localizer:           localizer,
+	}), fake
+}
+
+func TestResourceConnectorNamespaceLifecycle(t *testing.T) {
+	factory, fake := testConnectorFactory(t, map[string][]string{
+		"test-namespace": {StateDisconnected, StateReady},
+	})
+
+	r := ResourceConnectorNamespace(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField: "test-namespace",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, fake.evaluation, "expected an evaluation namespace without a cluster id")
+	assert.Equal(t, "namespace-test-namespace", d.Id())
+	assert.Equal(t, StateReady, d.Get(StatusField))
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.namespaces)
+}
+
+func testConnectorConfig() map[string]interface{} {
+	return map[string]interface{}{
+		NameField:            "test-connector",
+		NamespaceIDField:     "test-namespace",
+		ConnectorTypeIDField: "log_sink_0.1",
+		KafkaIDField:         "test-kafka-id",
+		ClientIDField:        "test-client-id",
+		ClientSecretField:    "test-client-secret",
+		ConnectorField:       `{"kafka_topic": "orders"}`,
+	}
+}
+
+func TestResourceConnectorLifecycle(t *testing.T) {
+	factory, fake := testConnectorFactory(t, map[string][]string{
+		"test-connector": {"assigned", "provisioning"},
+	})
+
+	r := ResourceConnector(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, testConnectorConfig())
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "connector-test-connector", d.Id())
+	assert.Equal(t, StateReady, d.Get(StatusField))
+	assert.Equal(t, "test-kafka.example.com:443", fake.connectors[d.Id()].Kafka.URL)
+	assert.Equal(t, DefaultChannel, fake.connectors[d.Id()].Channel)
+
+	state := d.State()
+	config := testConnectorConfig()
+	config[DesiredStateField] = StateStopped
+	d = testUpdateResourceData(t, r, state, config)
+
+	diags = r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, StateStopped, d.Get(StatusField))
+	assert.Equal(t, []map[string]interface{}{{"desired_state": StateStopped}}, fake.patches, "expected only the desired state to be patched")
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.connectors)
+}
+
+func TestResourceConnectorReformattedSpecIsNotPatched(t *testing.T) {
+	factory, fake := testConnectorFactory(t, nil)
+
+	r := ResourceConnector(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, testConnectorConfig())
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	config := testConnectorConfig()
+	config[ConnectorField] = "{\n  \"kafka_topic\": \"orders\"\n}"
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "expected no diff for an equivalent connector spec, got %v", diff)
+	assert.Empty(t, fake.patches)
+}
+
+func TestResourceConnectorRemovedSpecMembersArePatched(t *testing.T) {
+	factory, fake := testConnectorFactory(t, nil)
+
+	r := ResourceConnector(factory.Localizer())
+	config := testConnectorConfig()
+	config[ConnectorField] = `{"kafka_topic": "orders", "log_level": "debug"}`
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	d = testUpdateResourceData(t, r, d.State(), testConnectorConfig())
+
+	diags = r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []map[string]interface{}{
+		{"connector": map[string]interface{}{"kafka_topic": "orders", "log_level": nil}},
+	}, fake.patches, "expected the removed member to be set to null")
+}
+
+func TestResourceConnectorImportedSpecIsPatched(t *testing.T) {
+	factory, fake := testConnectorFactory(t, nil)
+
+	r := ResourceConnector(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, testConnectorConfig())
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	imported := r.TestResourceData()
+	imported.SetId(d.Id())
+	_, err := r.Importer.StateContext(context.Background(), imported, factory)
+	assert.NoError(t, err)
+
+	diags = r.ReadContext(context.Background(), imported, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", imported.Get(ConnectorField), "expected the connector spec not to be read back")
+
+	d = testUpdateResourceData(t, r, imported.State(), testConnectorConfig())
+
+	diags = r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, fake.patches, 1) {
+		assert.Equal(t, map[string]interface{}{"kafka_topic": "orders"}, fake.patches[0]["connector"], "expected the whole spec to be patched")
+	}
+}
+
+func TestResourceConnectorSpecValidation(t *testing.T) {
+	factory, fake := testConnectorFactory(t, nil)
+	fake.types = []connectormgmt.ConnectorType{
+		{
+			ID:   "log_sink_0.1",
+			Name: "Log Sink",
+			Schema: []byte(`{
+				"type": "object",
+				"required": ["kafka_topic"],
+				"additionalProperties": false,
+				"properties": {
+					"kafka_topic": {"type": "string"},
+					"log_multiline": {"type": "boolean"},
+					"log_level": {"type": "string", "enum": ["INFO", "DEBUG"]},
+					"processors": {"type": "object", "properties": {"limit": {"type": "integer"}}},
+					"aws_secret_key": {"oneOf": [{"type": "string"}, {"type": "object"}]}
+				}
+			}`),
+		},
+	}
+
+	r := ResourceConnector(factory.Localizer())
+
+	tests := []struct {
+		name            string
+		connectorTypeID string
+		connector       string
+		wantErr         string
+	}{
+		{name: "valid", connector: `{"kafka_topic": "orders", "log_level": "DEBUG", "processors": {"limit": 10}, "aws_secret_key": {"ref": "secret"}}`},
+		{name: "missing required member", connector: `{"log_multiline": true}`, wantErr: `connector: missing required member "kafka_topic"`},
+		{name: "wrong type", connector: `{"kafka_topic": 1}`, wantErr: "connector.kafka_topic: expected string"},
+		{name: "not an enum value", connector: `{"kafka_topic": "orders", "log_level": "TRACE"}`, wantErr: `connector.log_level: expected one of ["INFO","DEBUG"]`},
+		{name: "nested wrong type", connector: `{"kafka_topic": "orders", "processors": {"limit": 1.5}}`, wantErr: "connector.processors.limit: expected integer"},
+		{name: "unexpected member", connector: `{"kafka_topic": "orders", "topic": "orders"}`, wantErr: `connector: unexpected member "topic"`},
+		{name: "unknown connector type", connectorTypeID: "missing_0.1", connector: `{}`, wantErr: `connector_type_id: the connector type "missing_0.1" does not exist`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConnectorConfig()
+			config[ConnectorField] = tt.connector // nolint:scopelint
+			if tt.connectorTypeID != "" {         // nolint:scopelint
+				config[ConnectorTypeIDField] = tt.connectorTypeID // nolint:scopelint
+			}
+
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), factory)
+			if tt.wantErr == "" { // nolint:scopelint
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr) // nolint:scopelint
+			}
+		})
+	}
+}
+
+func TestResourceConnectorFailure(t *testing.T) {
+	factory, _ := testConnectorFactory(t, map[string][]string{
+		"test-connector": {"provisioning", StateFailed},
+	})
+
+	r := ResourceConnector(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, testConnectorConfig())
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "the connector could not connect")
+	assert.Equal(t, "connector-test-connector", d.Id(), "expected the failed connector to be kept so it is tainted")
+}
+
+func TestResourceConnectorReadDeleted(t *testing.T) {
+	factory, _ := testConnectorFactory(t, nil)
+
+	r := ResourceConnector(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("deleted-connector")
+
+	diags := r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id(), "expected the connector deleted outside of terraform to be removed from the state")
+}
+
+func TestDataSourceConnectorTypesRead(t *testing.T) {
+	factory, fake := testConnectorFactory(t, nil)
+	fake.types = []connectormgmt.ConnectorType{
+		{
+			ID:          "log_sink_0.1",
+			Name:        "Log Sink",
+			Version:     "0.1",
+			Description: "Log the events of a topic",
+			Channels:    []string{"stable"},
+			Labels:      []string{"sink"},
+			Schema:      []byte(`{"type":"object","required":["kafka_topic"]}`),
+		},
+	}
+
+	r := DataSourceConnectorTypes(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField:    "Log%",
+		LabelField:   "sink",
+		ChannelField: "stable",
+	})
+
+	diags := r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "name like 'Log%' and label = 'sink' and channel = 'stable'", fake.search)
+	assert.Equal(t, 1, d.Get(ConnectorTypesField+".#"))
+	assert.Equal(t, "log_sink_0.1", d.Get(ConnectorTypesField+".0."+IDField))
+	assert.Equal(t, "sink", d.Get(ConnectorTypesField+".0."+LabelsField+".0"))
+	assert.JSONEq(t, `{"type":"object","required":["kafka_topic"]}`, d.Get(ConnectorTypesField+".0."+SchemaField).(string))
+}
+
+func TestBuildConnectorTypesSearchQuery(t *testing.T) {
+	assert.Equal(t, "", buildConnectorTypesSearchQuery("", "", ""))
+	assert.Equal(t, "name = 'Log Sink'", buildConnectorTypesSearchQuery("Log Sink", "", ""))
+	assert.Equal(t, "label = 'source' and channel = 'beta'", buildConnectorTypesSearchQuery("", "source", "beta"))
+	assert.Equal(t, "name = 'O''Reilly'", buildConnectorTypesSearchQuery("O'Reilly", "", ""))
+}
+
+func testUpdateResourceData(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
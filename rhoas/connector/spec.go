@@ -0,0 +1,113 @@
+package connector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// specErrors returns how the value does not match the json schema, each error
+// prefixed with the path of the value. Only the type, enum, required,
+// properties and additionalProperties keywords are checked, the others are
+// left to the api which validates the whole schema when the connector is applied
+func specErrors(schema map[string]interface{}, value interface{}, path string) []string {
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !hasSchemaType(types, value) {
+		return []string{fmt.Sprintf("%s: expected %s", path, strings.Join(types, " or "))}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsJSON(enum, value) {
+		allowed, _ := json.Marshal(enum)
+		return []string{fmt.Sprintf("%s: expected one of %s", path, allowed)}
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var errs []string
+
+	required, _ := schema["required"].([]interface{})
+	for _, r := range required {
+		if name, ok := r.(string); ok {
+			if _, found := object[name]; !found {
+				errs = append(errs, fmt.Sprintf("%s: missing required member %q", path, name))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, name := range names {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			errs = append(errs, specErrors(property, object[name], path+"."+name)...)
+		} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+			errs = append(errs, fmt.Sprintf("%s: unexpected member %q", path, name))
+		}
+	}
+
+	return errs
+}
+
+// schemaTypes returns the types allowed by the type keyword, a single type or a list of types
+func schemaTypes(keyword interface{}) []string {
+	switch t := keyword.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if name, ok := v.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+
+	return nil
+}
+
+// hasSchemaType returns whether the decoded json value is of one of the json schema types
+func hasSchemaType(types []string, value interface{}) bool {
+	for _, t := range types {
+		var ok bool
+		switch v := value.(type) {
+		case nil:
+			ok = t == "null"
+		case bool:
+			ok = t == "boolean"
+		case string:
+			ok = t == "string"
+		case float64:
+			ok = t == "number" || (t == "integer" && v == math.Trunc(v))
+		case []interface{}:
+			ok = t == "array"
+		case map[string]interface{}:
+			ok = t == "object"
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
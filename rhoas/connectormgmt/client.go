@@ -0,0 +1,257 @@
+package connectormgmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
+)
+
+const (
+	basePath = "/api/connector_mgmt/v1"
+
+	// the number of connector types requested for each page of the list api
+	connectorTypesPageSize = 100
+)
+
+// APIClient calls the Red Hat OpenShift Connectors management api. It stands
+// in for the connectormgmt module of the app services sdk, which the provider
+// does not depend on yet
+type APIClient struct {
+	client *restapi.Client
+}
+
+// ConnectorNamespace is a namespace of an OpenShift cluster the connectors are deployed to
+type ConnectorNamespace struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	ClusterID   string                   `json:"cluster_id,omitempty"`
+	Owner       string                   `json:"owner,omitempty"`
+	Expiration  string                   `json:"expiration,omitempty"`
+	Annotations map[string]string        `json:"annotations,omitempty"`
+	Status      ConnectorNamespaceStatus `json:"status"`
+	CreatedAt   string                   `json:"created_at,omitempty"`
+	ModifiedAt  string                   `json:"modified_at,omitempty"`
+}
+
+// ConnectorNamespaceStatus is the state of a connector namespace, e.g. disconnected or ready
+type ConnectorNamespaceStatus struct {
+	State              string `json:"state"`
+	ConnectorsDeployed int    `json:"connectors_deployed"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ConnectorNamespaceRequest is the request to create a connector namespace.
+// Without a cluster id an evaluation namespace is created
+type ConnectorNamespaceRequest struct {
+	Name        string            `json:"name"`
+	ClusterID   string            `json:"cluster_id,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Connector is a connector deployed to a namespace
+type Connector struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	ConnectorTypeID string            `json:"connector_type_id"`
+	NamespaceID     string            `json:"namespace_id"`
+	Channel         string            `json:"channel,omitempty"`
+	DesiredState    string            `json:"desired_state"`
+	Kafka           KafkaConnection   `json:"kafka"`
+	ServiceAccount  ServiceAccount    `json:"service_account"`
+	Connector       json.RawMessage   `json:"connector,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	Owner           string            `json:"owner,omitempty"`
+	ResourceVersion int64             `json:"resource_version,omitempty"`
+	Status          ConnectorStatus   `json:"status"`
+	CreatedAt       string            `json:"created_at,omitempty"`
+	ModifiedAt      string            `json:"modified_at,omitempty"`
+}
+
+// ConnectorStatus is the state of a connector, e.g. assigning, ready or failed
+type ConnectorStatus struct {
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// KafkaConnection is the Kafka instance a connector reads from or writes to
+type KafkaConnection struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// ServiceAccount is the service account a connector authenticates to its Kafka instance with
+type ServiceAccount struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// ConnectorRequest is the request to create a connector
+type ConnectorRequest struct {
+	Name            string          `json:"name"`
+	ConnectorTypeID string          `json:"connector_type_id"`
+	NamespaceID     string          `json:"namespace_id"`
+	Channel         string          `json:"channel,omitempty"`
+	DesiredState    string          `json:"desired_state"`
+	Kafka           KafkaConnection `json:"kafka"`
+	ServiceAccount  ServiceAccount  `json:"service_account"`
+	Connector       json.RawMessage `json:"connector"`
+}
+
+// ConnectorType is a type of connector of the catalog, along with the JSON
+// schema of the configuration of its connectors
+type ConnectorType struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Description string          `json:"description,omitempty"`
+	Channels    []string        `json:"channels,omitempty"`
+	Labels      []string        `json:"labels,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+}
+
+// ConnectorTypeList is a page of connector types
+type ConnectorTypeList struct {
+	Page  int             `json:"page"`
+	Size  int             `json:"size"`
+	Total int             `json:"total"`
+	Items []ConnectorType `json:"items"`
+}
+
+// API is the Connectors management api used by the provider. The factory
+// returns it rather than the APIClient so that it can be replaced in tests
+type API interface {
+	CreateConnectorNamespace(ctx context.Context, namespace ConnectorNamespaceRequest) (ConnectorNamespace, *http.Response, error)
+	GetConnectorNamespace(ctx context.Context, id string) (ConnectorNamespace, *http.Response, error)
+	DeleteConnectorNamespace(ctx context.Context, id string) (*http.Response, error)
+	CreateConnector(ctx context.Context, connector ConnectorRequest) (Connector, *http.Response, error)
+	GetConnector(ctx context.Context, id string) (Connector, *http.Response, error)
+	PatchConnector(ctx context.Context, id string, patch map[string]interface{}) (Connector, *http.Response, error)
+	DeleteConnector(ctx context.Context, id string) (*http.Response, error)
+	GetConnectorTypes(ctx context.Context, search string, page int) (ConnectorTypeList, *http.Response, error)
+	ListConnectorTypes(ctx context.Context, search string) ([]ConnectorType, *http.Response, error)
+	GetConnectorType(ctx context.Context, id string) (ConnectorType, *http.Response, error)
+}
+
+var _ API = &APIClient{}
+
+func NewAPIClient(httpClient *http.Client, baseURL string) *APIClient {
+	return &APIClient{
+		client: restapi.NewClient(httpClient, baseURL),
+	}
+}
+
+// CreateConnectorNamespace requests a new connector namespace on a cluster, or
+// a new evaluation namespace when the request has no cluster id
+func (c *APIClient) CreateConnectorNamespace(ctx context.Context, namespace ConnectorNamespaceRequest) (ConnectorNamespace, *http.Response, error) {
+	path := basePath + "/kafka_connector_namespaces"
+	if namespace.ClusterID == "" {
+		path += "/eval"
+	}
+
+	var created ConnectorNamespace
+	resp, err := c.client.Do(ctx, http.MethodPost, path, nil, namespace, &created)
+
+	return created, resp, err
+}
+
+// GetConnectorNamespace returns the connector namespace with the given id
+func (c *APIClient) GetConnectorNamespace(ctx context.Context, id string) (ConnectorNamespace, *http.Response, error) {
+	var namespace ConnectorNamespace
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/kafka_connector_namespaces/%s", basePath, url.PathEscape(id)), nil, &namespace)
+
+	return namespace, resp, err
+}
+
+// DeleteConnectorNamespace requests the deletion of the connector namespace with
+// the given id along with its connectors, which are deleted asynchronously
+func (c *APIClient) DeleteConnectorNamespace(ctx context.Context, id string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, fmt.Sprintf("%s/kafka_connector_namespaces/%s", basePath, url.PathEscape(id)), nil, nil, nil)
+}
+
+// CreateConnector requests a new connector, which is deployed asynchronously
+func (c *APIClient) CreateConnector(ctx context.Context, connector ConnectorRequest) (Connector, *http.Response, error) {
+	query := url.Values{}
+	query.Set("async", "true")
+
+	var created Connector
+	resp, err := c.client.Do(ctx, http.MethodPost, basePath+"/kafka_connectors", query, connector, &created)
+
+	return created, resp, err
+}
+
+// GetConnector returns the connector with the given id
+func (c *APIClient) GetConnector(ctx context.Context, id string) (Connector, *http.Response, error) {
+	var connector Connector
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/kafka_connectors/%s", basePath, url.PathEscape(id)), nil, &connector)
+
+	return connector, resp, err
+}
+
+// PatchConnector changes the fields of the connector set in the json merge patch
+func (c *APIClient) PatchConnector(ctx context.Context, id string, patch map[string]interface{}) (Connector, *http.Response, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return Connector{}, nil, err
+	}
+
+	var connector Connector
+	resp, err := c.client.Do(ctx, http.MethodPatch, fmt.Sprintf("%s/kafka_connectors/%s", basePath, url.PathEscape(id)), nil, restapi.Content{
+		ContentType: "application/merge-patch+json",
+		Data:        string(data),
+	}, &connector)
+
+	return connector, resp, err
+}
+
+// DeleteConnector requests the deletion of the connector with the given id,
+// which is deleted asynchronously
+func (c *APIClient) DeleteConnector(ctx context.Context, id string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, fmt.Sprintf("%s/kafka_connectors/%s", basePath, url.PathEscape(id)), nil, nil, nil)
+}
+
+// GetConnectorTypes returns a page of the connector types matching the search
+// query, e.g. "label = 'sink' and channel = 'stable'"
+func (c *APIClient) GetConnectorTypes(ctx context.Context, search string, page int) (ConnectorTypeList, *http.Response, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(connectorTypesPageSize))
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	var list ConnectorTypeList
+	resp, err := c.client.Get(ctx, basePath+"/kafka_connector_types", query, &list)
+
+	return list, resp, err
+}
+
+// ListConnectorTypes returns all of the connector types matching the search query
+func (c *APIClient) ListConnectorTypes(ctx context.Context, search string) ([]ConnectorType, *http.Response, error) {
+	connectorTypes := make([]ConnectorType, 0)
+
+	for page := 1; ; page++ {
+		list, resp, err := c.GetConnectorTypes(ctx, search, page)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		connectorTypes = append(connectorTypes, list.Items...)
+
+		if len(list.Items) < connectorTypesPageSize || len(connectorTypes) >= list.Total {
+			return connectorTypes, resp, nil
+		}
+	}
+}
+
+// GetConnectorType returns the connector type with the given id
+func (c *APIClient) GetConnectorType(ctx context.Context, id string) (ConnectorType, *http.Response, error) {
+	var connectorType ConnectorType
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/kafka_connector_types/%s", basePath, url.PathEscape(id)), nil, &connectorType)
+
+	return connectorType, resp, err
+}
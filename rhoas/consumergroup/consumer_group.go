@@ -0,0 +1,163 @@
+package consumergroup
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+const (
+	KafkaIDField              = "kafka_id"
+	GroupIDField              = "group_id"
+	TopicField                = "topic"
+	StateField                = "state"
+	MembersField              = "members"
+	ActiveConsumersField      = "active_consumers"
+	LaggingPartitionsField    = "lagging_partitions"
+	UnassignedPartitionsField = "unassigned_partitions"
+	TotalLagField             = "total_lag"
+	PartitionsField           = "partitions"
+	PartitionField            = "partition"
+	MemberIDField             = "member_id"
+	CommittedOffsetField      = "committed_offset"
+	LogEndOffsetField         = "log_end_offset"
+	LagField                  = "lag"
+)
+
+// consumerGroupSchema returns the attributes of a consumer group read from the
+// admin api of a kafka instance
+func consumerGroupSchema(localizer localize.Localizer) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		GroupIDField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.groupID"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		StateField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.state"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		MembersField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.members"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		ActiveConsumersField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.activeConsumers"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		LaggingPartitionsField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.laggingPartitions"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		UnassignedPartitionsField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.unassignedPartitions"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		TotalLagField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.totalLag"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		PartitionsField: {
+			Description: localizer.MustLocalize("consumergroup.field.description.partitions"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					TopicField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.partitionTopic"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					PartitionField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.partition"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					MemberIDField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.memberID"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					CommittedOffsetField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.committedOffset"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					LogEndOffsetField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.logEndOffset"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					LagField: {
+						Description: localizer.MustLocalize("consumergroup.field.description.lag"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// mapConsumerGroupToData maps a consumer group returned by the admin api to the
+// attributes of the consumer group data sources
+func mapConsumerGroupToData(group *kafkainstanceclient.ConsumerGroup) map[string]interface{} {
+	totalLag := 0
+
+	partitions := make([]interface{}, len(group.GetConsumers()))
+	for i, consumer := range group.GetConsumers() {
+		totalLag += int(consumer.GetLag())
+
+		partitions[i] = map[string]interface{}{
+			TopicField:           consumer.GetTopic(),
+			PartitionField:       int(consumer.GetPartition()),
+			MemberIDField:        consumer.GetMemberId(),
+			CommittedOffsetField: int(consumer.GetOffset()),
+			LogEndOffsetField:    int(consumer.GetLogEndOffset()),
+			LagField:             int(consumer.GetLag()),
+		}
+	}
+
+	metrics := group.GetMetrics()
+
+	return map[string]interface{}{
+		GroupIDField:              group.GetGroupId(),
+		StateField:                string(group.GetState()),
+		MembersField:              groupMembers(group),
+		ActiveConsumersField:      int(metrics.GetActiveConsumers()),
+		LaggingPartitionsField:    int(metrics.GetLaggingPartitions()),
+		UnassignedPartitionsField: int(metrics.GetUnassignedPartitions()),
+		TotalLagField:             totalLag,
+		PartitionsField:           partitions,
+	}
+}
+
+// groupMembers returns the ids of the members of the consumer group, sorted so
+// the same group always produces the same attributes
+func groupMembers(group *kafkainstanceclient.ConsumerGroup) []string {
+	members := make([]string, 0)
+	seen := map[string]bool{}
+
+	for _, consumer := range group.GetConsumers() {
+		memberID := consumer.GetMemberId()
+		if memberID != "" && !seen[memberID] {
+			seen[memberID] = true
+			members = append(members, memberID)
+		}
+	}
+
+	sort.Strings(members)
+
+	return members
+}
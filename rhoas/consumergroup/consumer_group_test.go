@@ -0,0 +1,125 @@
+package consumergroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	kafkainstance "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConsumer(topic string, partition int32, memberID string, offset int64, logEndOffset int64) kafkainstanceclient.Consumer {
+	consumer := kafkainstanceclient.NewConsumer("test-group", topic, partition, offset, logEndOffset-offset)
+	consumer.SetLogEndOffset(logEndOffset)
+	if memberID != "" {
+		consumer.SetMemberId(memberID)
+	}
+
+	return *consumer
+}
+
+func TestMapConsumerGroupToData(t *testing.T) {
+	group := kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+		testConsumer("orders", 0, "member-b", 10, 15),
+		testConsumer("orders", 1, "member-a", 20, 20),
+		testConsumer("orders", 2, "member-b", 5, 7),
+		testConsumer("payments", 0, "", 3, 3),
+	})
+	group.SetState(kafkainstanceclient.CONSUMERGROUPSTATE_STABLE)
+	group.SetMetrics(kafkainstanceclient.ConsumerGroupMetrics{
+		ActiveConsumers:      int32Ptr(2),
+		LaggingPartitions:    int32Ptr(2),
+		UnassignedPartitions: int32Ptr(1),
+	})
+
+	data := mapConsumerGroupToData(group)
+
+	assert.Equal(t, "test-group", data[GroupIDField])
+	assert.Equal(t, "STABLE", data[StateField])
+	assert.Equal(t, []string{"member-a", "member-b"}, data[MembersField], "expected the distinct members sorted")
+	assert.Equal(t, 2, data[ActiveConsumersField])
+	assert.Equal(t, 1, data[UnassignedPartitionsField])
+	assert.Equal(t, 7, data[TotalLagField])
+
+	partitions, ok := data[PartitionsField].([]interface{})
+	if !ok {
+		t.Fatalf("expected %s to be a list", PartitionsField)
+	}
+	assert.Len(t, partitions, 4)
+	assert.Equal(t, map[string]interface{}{
+		TopicField:           "orders",
+		PartitionField:       0,
+		MemberIDField:        "member-b",
+		CommittedOffsetField: 10,
+		LogEndOffsetField:    15,
+		LagField:             5,
+	}, partitions[0])
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestListConsumerGroups(t *testing.T) {
+	const total = consumerGroupsPageSize + 10
+
+	var filters []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		filters = append(filters, r.URL.Query().Get("group-id-filter"))
+
+		// the api matches the ids containing the filter, half of the groups
+		// only contain it and the other half start with it
+		items := make([]kafkainstanceclient.ConsumerGroup, 0)
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			id := fmt.Sprintf("app-%d", i)
+			if i%2 == 1 {
+				id = fmt.Sprintf("other-app-%d", i)
+			}
+			items = append(items, *kafkainstanceclient.NewConsumerGroup(id, []kafkainstanceclient.Consumer{}))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(kafkainstanceclient.NewConsumerGroupList(items, total))
+	}))
+	defer server.Close()
+
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		HTTPClient: server.Client(),
+		Localizer:  localizer,
+	})
+	instanceAPI := kafkainstance.NewAPIClient(&kafkainstance.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	groups, err := listConsumerGroups(context.Background(), factory, instanceAPI, "app-", "")
+	assert.NoError(t, err, "unexpected error listing consumer groups")
+	assert.Len(t, groups, total/2, "expected only the groups starting with the prefix")
+	assert.Equal(t, []string{"app-", "app-"}, filters, "expected every page to be requested with the filter")
+
+	for _, group := range groups {
+		assert.Regexp(t, "^app-", group.GetGroupId())
+	}
+}
+
+func TestConsumesFromTopic(t *testing.T) {
+	group := kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+		testConsumer("orders", 0, "", 0, 0),
+	})
+
+	assert.True(t, consumesFromTopic(group, ""))
+	assert.True(t, consumesFromTopic(group, "orders"))
+	assert.False(t, consumesFromTopic(group, "order"), "expected the topic to match exactly")
+}
@@ -0,0 +1,87 @@
+package consumergroup
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+func DataSourceConsumerGroup(localizer localize.Localizer) *schema.Resource {
+	groupSchema := consumerGroupSchema(localizer)
+
+	groupSchema[KafkaIDField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.field.description.kafkaID"),
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	groupSchema[GroupIDField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.field.description.groupID"),
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	groupSchema[TopicField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.datasource.field.description.topic"),
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	return &schema.Resource{
+		Description: "`rhoas_consumer_group` provides a consumer group of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, including the lag of its partitions.",
+		ReadContext: dataSourceConsumerGroupRead,
+		Schema:      groupSchema,
+	}
+}
+
+func dataSourceConsumerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	groupID, ok := d.Get(GroupIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDField)))
+	}
+
+	topic, ok := d.Get(TopicField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TopicField)))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request := instanceAPI.GroupsApi.GetConsumerGroupById(ctx, groupID)
+	if topic != "" {
+		request = request.Topic(topic)
+	}
+
+	group, resp, err := request.Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	for field, value := range mapConsumerGroupToData(&group) {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(groupID)
+
+	return diags
+}
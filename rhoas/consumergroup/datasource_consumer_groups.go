@@ -0,0 +1,154 @@
+package consumergroup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	ConsumerGroupsField = "consumer_groups"
+	GroupIDPrefixField  = "group_id_prefix"
+
+	// the number of consumer groups requested for each page of the list api
+	consumerGroupsPageSize = 100
+)
+
+func DataSourceConsumerGroups(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_consumer_groups` provides a list of the consumer groups of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, including the lag of their partitions.",
+		ReadContext: dataSourceConsumerGroupsRead,
+		Schema: map[string]*schema.Schema{
+			KafkaIDField: {
+				Description: localizer.MustLocalize("consumergroup.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			GroupIDPrefixField: {
+				Description: localizer.MustLocalize("consumergroup.datasource.field.description.groupIDPrefix"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			TopicField: {
+				Description: localizer.MustLocalize("consumergroup.datasource.field.description.filterTopic"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			ConsumerGroupsField: {
+				Description: localizer.MustLocalize("consumergroup.datasource.field.description.consumerGroups"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: consumerGroupSchema(localizer),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsumerGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	prefix, ok := d.Get(GroupIDPrefixField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDPrefixField)))
+	}
+
+	topic, ok := d.Get(TopicField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TopicField)))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups, err := listConsumerGroups(ctx, factory, instanceAPI, prefix, topic)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := make([]interface{}, len(groups))
+	for i := range groups {
+		data[i] = mapConsumerGroupToData(&groups[i])
+	}
+
+	if err = d.Set(ConsumerGroupsField, data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(kafkaID)
+
+	return diags
+}
+
+// listConsumerGroups returns every consumer group whose id starts with the
+// prefix and which consumes from the topic, when they are given. The api only
+// filters the groups by ids and topics containing the values, so the groups
+// are filtered again here
+func listConsumerGroups(ctx context.Context, factory rhoasAPI.Factory, instanceAPI *kafkainstanceclient.APIClient, prefix string, topic string) ([]kafkainstanceclient.ConsumerGroup, error) {
+	groups := make([]kafkainstanceclient.ConsumerGroup, 0)
+	read := 0
+
+	for page := int32(1); ; page++ {
+		request := instanceAPI.GroupsApi.GetConsumerGroups(ctx).
+			Page(page).
+			Size(consumerGroupsPageSize)
+
+		if prefix != "" {
+			request = request.GroupIdFilter(prefix)
+		}
+		if topic != "" {
+			request = request.Topic(topic)
+		}
+
+		list, resp, err := request.Execute()
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+
+		items := list.GetItems()
+		for i := range items {
+			if strings.HasPrefix(items[i].GetGroupId(), prefix) && consumesFromTopic(&items[i], topic) {
+				groups = append(groups, items[i])
+			}
+		}
+
+		read += len(items)
+		if len(items) < consumerGroupsPageSize || read >= int(list.GetTotal()) {
+			return groups, nil
+		}
+	}
+}
+
+func consumesFromTopic(group *kafkainstanceclient.ConsumerGroup, topic string) bool {
+	if topic == "" {
+		return true
+	}
+
+	for _, consumer := range group.GetConsumers() {
+		if consumer.GetTopic() == topic {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,221 @@
+package consumergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	ForceField = "force"
+
+	// separates the kafka id from the group id in the id of the resource
+	idSeparator = "/"
+)
+
+func ResourceConsumerGroup(localizer localize.Localizer) *schema.Resource {
+	groupSchema := consumerGroupSchema(localizer)
+
+	groupSchema[KafkaIDField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.field.description.kafkaID"),
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	groupSchema[GroupIDField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.field.description.groupID"),
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	groupSchema[ForceField] = &schema.Schema{
+		Description: localizer.MustLocalize("consumergroup.resource.field.description.force"),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+
+	return &schema.Resource{
+		Description:   "`rhoas_consumer_group` manages the lifecycle of a consumer group of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka. Consumer groups are created by Kafka when their first consumer joins, so the group may be declared before it exists. The group is deleted when the resource is destroyed.",
+		CreateContext: consumerGroupCreate,
+		ReadContext:   consumerGroupRead,
+		UpdateContext: consumerGroupUpdate,
+		DeleteContext: consumerGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: consumerGroupImport,
+		},
+		Schema: groupSchema,
+	}
+}
+
+func consumerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	groupID, ok := d.Get(GroupIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDField)))
+	}
+
+	// there is nothing to create, kafka creates the group when a consumer joins
+	// it, so an existing group is adopted and a missing one is read once it exists
+	d.SetId(kafkaID + idSeparator + groupID)
+
+	return consumerGroupRead(ctx, d, m)
+}
+
+func consumerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	groupID, ok := d.Get(GroupIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDField)))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, err := getConsumerGroup(ctx, factory, instanceAPI, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if group == nil {
+		// the group does not exist yet or anymore, it is kept in the state
+		// with no members until a consumer joins it
+		group = kafkainstanceclient.NewConsumerGroup(groupID, []kafkainstanceclient.Consumer{})
+	}
+
+	for field, value := range mapConsumerGroupToData(group) {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func consumerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// every argument identifying the group forces a new group, the remaining
+	// arguments only change the behaviour of the provider
+	return consumerGroupRead(ctx, d, m)
+}
+
+func consumerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	force, ok := d.Get(ForceField).(bool)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ForceField)))
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	groupID, ok := d.Get(GroupIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDField)))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, err := getConsumerGroup(ctx, factory, instanceAPI, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if group == nil {
+		// the group never existed or is already gone
+		d.SetId("")
+		return diags
+	}
+
+	if members := groupMembers(group); len(members) > 0 && !force {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("consumergroup.errors.deleteActiveMembers", localize.NewEntry("GroupID", group.GetGroupId()), localize.NewEntry("Members", strings.Join(members, ", ")), localize.NewEntry("Field", ForceField)))
+	}
+
+	resp, err := instanceAPI.GroupsApi.DeleteConsumerGroupById(ctx, groupID).Execute()
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// consumerGroupImport imports a consumer group from an id of the form <kafka_id>/<group_id>
+func consumerGroupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	kafkaID, groupID, found := strings.Cut(d.Id(), idSeparator)
+	if !found || kafkaID == "" || groupID == "" {
+		return nil, fmt.Errorf("unexpected id %q, expected <kafka_id>%s<group_id>", d.Id(), idSeparator)
+	}
+
+	if err := d.Set(KafkaIDField, kafkaID); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(GroupIDField, groupID); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(ForceField, false); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getConsumerGroup returns the consumer group with the given id, or nil when it does not exist
+func getConsumerGroup(ctx context.Context, factory rhoasAPI.Factory, instanceAPI *kafkainstanceclient.APIClient, groupID string) (*kafkainstanceclient.ConsumerGroup, error) {
+	group, resp, err := instanceAPI.GroupsApi.GetConsumerGroupById(ctx, groupID).Execute()
+	if resp != nil && utils.CheckNotFound(resp) {
+		return nil, nil
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return &group, nil
+}
@@ -0,0 +1,254 @@
+package consumergroup
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	StrategyField = "strategy"
+	ValueField    = "value"
+	TriggersField = "triggers"
+	OffsetsField  = "offsets"
+	OffsetField   = "offset"
+)
+
+// resetStrategies are the strategies supported by the admin api to reset the
+// offsets of a consumer group
+var resetStrategies = []string{
+	string(kafkainstanceclient.OFFSETTYPE_EARLIEST),
+	string(kafkainstanceclient.OFFSETTYPE_LATEST),
+	string(kafkainstanceclient.OFFSETTYPE_TIMESTAMP),
+	string(kafkainstanceclient.OFFSETTYPE_ABSOLUTE),
+}
+
+// nolint:funlen
+func ResourceConsumerGroupOffsetReset(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_consumer_group_offset_reset` resets the offsets of a consumer group of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka. The offsets are reset again whenever an argument, such as `triggers`, changes.",
+		CreateContext: consumerGroupOffsetResetCreate,
+		ReadContext:   consumerGroupOffsetResetRead,
+		DeleteContext: consumerGroupOffsetResetDelete,
+		Schema: map[string]*schema.Schema{
+			KafkaIDField: {
+				Description: localizer.MustLocalize("consumergroup.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			GroupIDField: {
+				Description: localizer.MustLocalize("consumergroup.field.description.groupID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			StrategyField: {
+				Description:  localizer.MustLocalize("consumergroup.offsetreset.field.description.strategy", localize.NewEntry("Strategies", strings.Join(resetStrategies, ", "))),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(resetStrategies, false),
+			},
+			ValueField: {
+				Description: localizer.MustLocalize("consumergroup.offsetreset.field.description.value"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			TopicField: {
+				Description: localizer.MustLocalize("consumergroup.offsetreset.field.description.topic"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			PartitionsField: {
+				Description:  localizer.MustLocalize("consumergroup.offsetreset.field.description.partitions"),
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{TopicField},
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			TriggersField: {
+				Description: localizer.MustLocalize("consumergroup.offsetreset.field.description.triggers"),
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			OffsetsField: {
+				Description: localizer.MustLocalize("consumergroup.offsetreset.field.description.offsets"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						TopicField: {
+							Description: localizer.MustLocalize("consumergroup.field.description.partitionTopic"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						PartitionField: {
+							Description: localizer.MustLocalize("consumergroup.field.description.partition"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						OffsetField: {
+							Description: localizer.MustLocalize("consumergroup.offsetreset.field.description.offset"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func consumerGroupOffsetResetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	groupID, ok := d.Get(GroupIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", GroupIDField)))
+	}
+
+	parameters, err := mapResourceDataToResetOffsetParameters(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, resp, err := instanceAPI.GroupsApi.GetConsumerGroupById(ctx, groupID).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	// kafka only resets the offsets of a group without members, so report the
+	// members still connected instead of the error of the api
+	if members := groupMembers(&group); len(members) > 0 {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("consumergroup.errors.resetActiveMembers", localize.NewEntry("GroupID", groupID), localize.NewEntry("Members", strings.Join(members, ", "))))
+	}
+
+	result, resp, err := instanceAPI.GroupsApi.ResetConsumerGroupOffset(ctx, groupID).ConsumerGroupResetOffsetParameters(*parameters).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	offsets := make([]interface{}, len(result.GetItems()))
+	for i, item := range result.GetItems() {
+		offsets[i] = map[string]interface{}{
+			TopicField:     item.GetTopic(),
+			PartitionField: int(item.GetPartition()),
+			OffsetField:    int(item.GetOffset()),
+		}
+	}
+
+	if err = d.Set(OffsetsField, offsets); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// offset resets have no id so we need to create a new random one for it
+	idNumber := rand.Intn(1_000_000_000) //nolint:gosec
+	d.SetId(groupID + strconv.Itoa(idNumber))
+
+	return diags
+}
+
+func consumerGroupOffsetResetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func consumerGroupOffsetResetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	return diags
+}
+
+func mapResourceDataToResetOffsetParameters(factory rhoasAPI.Factory, d *schema.ResourceData) (*kafkainstanceclient.ConsumerGroupResetOffsetParameters, error) {
+	strategy, ok := d.Get(StrategyField).(string)
+	if !ok {
+		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", StrategyField))
+	}
+
+	value, ok := d.Get(ValueField).(string)
+	if !ok {
+		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ValueField))
+	}
+
+	topic, ok := d.Get(TopicField).(string)
+	if !ok {
+		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TopicField))
+	}
+
+	partitionsInput, ok := d.Get(PartitionsField).([]interface{})
+	if !ok {
+		return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PartitionsField))
+	}
+
+	parameters := kafkainstanceclient.NewConsumerGroupResetOffsetParameters(kafkainstanceclient.OffsetType(strategy))
+
+	switch kafkainstanceclient.OffsetType(strategy) {
+	case kafkainstanceclient.OFFSETTYPE_TIMESTAMP:
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return nil, factory.Localizer().MustLocalizeError("consumergroup.errors.invalidResetValue", localize.NewEntry("Strategy", strategy), localize.NewEntry("Value", value), localize.NewEntry("Expected", "an RFC 3339 timestamp, e.g. 2022-01-02T03:04:05Z"))
+		}
+		parameters.SetValue(value)
+	case kafkainstanceclient.OFFSETTYPE_ABSOLUTE:
+		if offset, err := strconv.ParseInt(value, 10, 64); err != nil || offset < 0 {
+			return nil, factory.Localizer().MustLocalizeError("consumergroup.errors.invalidResetValue", localize.NewEntry("Strategy", strategy), localize.NewEntry("Value", value), localize.NewEntry("Expected", "a positive offset"))
+		}
+		parameters.SetValue(value)
+	}
+
+	if topic != "" {
+		target := kafkainstanceclient.NewTopicsToResetOffset(topic)
+
+		if len(partitionsInput) > 0 {
+			partitions := make([]int32, len(partitionsInput))
+			for i, partition := range partitionsInput {
+				p, ok := partition.(int)
+				if !ok {
+					return nil, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PartitionsField))
+				}
+				partitions[i] = int32(p)
+			}
+			target.SetPartitions(partitions)
+		}
+
+		parameters.SetTopics([]kafkainstanceclient.TopicsToResetOffset{*target})
+	}
+
+	return parameters, nil
+}
@@ -0,0 +1,144 @@
+package consumergroup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapResourceDataToResetOffsetParameters(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		Localizer: localizer,
+	})
+	r := ResourceConsumerGroupOffsetReset(localizer)
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "earliest ignores the value",
+			config: map[string]interface{}{StrategyField: "earliest", ValueField: "ignored"},
+			want:   `{"offset":"earliest"}`,
+		},
+		{
+			name:   "timestamp",
+			config: map[string]interface{}{StrategyField: "timestamp", ValueField: "2022-01-02T03:04:05Z"},
+			want:   `{"offset":"timestamp","value":"2022-01-02T03:04:05Z"}`,
+		},
+		{
+			name:    "invalid timestamp",
+			config:  map[string]interface{}{StrategyField: "timestamp", ValueField: "yesterday"},
+			wantErr: true,
+		},
+		{
+			name:   "absolute on partitions of a topic",
+			config: map[string]interface{}{StrategyField: "absolute", ValueField: "42", TopicField: "orders", PartitionsField: []interface{}{0, 2}},
+			want:   `{"offset":"absolute","value":"42","topics":[{"topic":"orders","partitions":[0,2]}]}`,
+		},
+		{
+			name:    "negative absolute offset",
+			config:  map[string]interface{}{StrategyField: "absolute", ValueField: "-1"},
+			wantErr: true,
+		},
+		{
+			name:   "latest on a topic",
+			config: map[string]interface{}{StrategyField: "latest", TopicField: "orders"},
+			want:   `{"offset":"latest","topics":[{"topic":"orders"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.config)
+
+			parameters, err := mapResourceDataToResetOffsetParameters(factory, d)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			data, _ := json.Marshal(parameters)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestConsumerGroupOffsetResetCreate(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceConsumerGroupOffsetReset(localizer)
+	config := map[string]interface{}{
+		KafkaIDField:  "test-kafka-id",
+		GroupIDField:  "test-group",
+		StrategyField: "earliest",
+	}
+
+	t.Run("records the reset offsets", func(t *testing.T) {
+		factory := fakeapi.KafkaInstanceFactory(t, func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/reset-offset") {
+				fakeapi.WriteJSON(w, http.StatusOK, kafkainstanceclient.ConsumerGroupResetOffsetResult{
+					Items: []kafkainstanceclient.ConsumerGroupResetOffsetResultItem{
+						{Topic: stringPtr("orders"), Partition: int32Ptr(0), Offset: int64Ptr(0)},
+						{Topic: stringPtr("orders"), Partition: int32Ptr(1), Offset: int64Ptr(3)},
+					},
+					Total: 2,
+				})
+				return
+			}
+
+			fakeapi.WriteJSON(w, http.StatusOK, kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+				testConsumer("orders", 0, "", 10, 10),
+			}))
+		})
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+		diags := consumerGroupOffsetResetCreate(context.Background(), d, factory)
+
+		assert.False(t, diags.HasError(), "unexpected error resetting the offsets: %v", diags)
+		assert.NotEmpty(t, d.Id())
+		assert.Equal(t, 2, d.Get(OffsetsField+".#"))
+		assert.Equal(t, 3, d.Get(OffsetsField+".1."+OffsetField))
+	})
+
+	t.Run("refuses to reset a group with active members", func(t *testing.T) {
+		reset := false
+		factory := fakeapi.KafkaInstanceFactory(t, func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/reset-offset") {
+				reset = true
+			}
+
+			fakeapi.WriteJSON(w, http.StatusOK, kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+				testConsumer("orders", 0, "member-a", 10, 10),
+			}))
+		})
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+		diags := consumerGroupOffsetResetCreate(context.Background(), d, factory)
+
+		assert.True(t, diags.HasError(), "expected the reset to be refused")
+		assert.Contains(t, diags[0].Summary, "member-a")
+		assert.False(t, reset, "expected the offsets not to be reset")
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,153 @@
+package consumergroup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// testGroupHandler serves the given consumer group, or a not found error when
+// it is nil, and records whether the group was deleted
+func testGroupHandler(group *kafkainstanceclient.ConsumerGroup, deleted *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if group == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			*deleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		fakeapi.WriteJSON(w, http.StatusOK, group)
+	}
+}
+
+func TestConsumerGroupDelete(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceConsumerGroup(localizer)
+
+	activeGroup := kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+		testConsumer("orders", 0, "member-a", 10, 10),
+	})
+	emptyGroup := kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+		testConsumer("orders", 0, "", 10, 10),
+	})
+
+	tests := []struct {
+		name        string
+		group       *kafkainstanceclient.ConsumerGroup
+		force       bool
+		wantErr     bool
+		wantDeleted bool
+	}{
+		{
+			name:        "empty group",
+			group:       emptyGroup,
+			wantDeleted: true,
+		},
+		{
+			name:    "group with active members",
+			group:   activeGroup,
+			wantErr: true,
+		},
+		{
+			name:        "forced deletion of a group with active members",
+			group:       activeGroup,
+			force:       true,
+			wantDeleted: true,
+		},
+		{
+			name:  "missing group",
+			group: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			factory := fakeapi.KafkaInstanceFactory(t, testGroupHandler(tt.group, &deleted))
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				KafkaIDField: "test-kafka-id",
+				GroupIDField: "test-group",
+				ForceField:   tt.force,
+			})
+			d.SetId("test-kafka-id/test-group")
+
+			diags := consumerGroupDelete(context.Background(), d, factory)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), "unexpected diagnostics: %v", diags)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			if !tt.wantErr {
+				assert.Equal(t, "", d.Id())
+			}
+		})
+	}
+}
+
+func TestConsumerGroupCreate(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceConsumerGroup(localizer)
+
+	t.Run("adopts an existing group", func(t *testing.T) {
+		group := kafkainstanceclient.NewConsumerGroup("test-group", []kafkainstanceclient.Consumer{
+			testConsumer("orders", 0, "member-a", 10, 12),
+		})
+		group.SetState(kafkainstanceclient.CONSUMERGROUPSTATE_STABLE)
+		factory := fakeapi.KafkaInstanceFactory(t, testGroupHandler(group, nil))
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{KafkaIDField: "test-kafka-id", GroupIDField: "test-group"})
+
+		diags := consumerGroupCreate(context.Background(), d, factory)
+
+		assert.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		assert.Equal(t, "test-kafka-id/test-group", d.Id())
+		assert.Equal(t, "STABLE", d.Get(StateField))
+		assert.Equal(t, []interface{}{"member-a"}, d.Get(MembersField))
+		assert.Equal(t, 2, d.Get(TotalLagField))
+	})
+
+	t.Run("declares a group which does not exist yet", func(t *testing.T) {
+		factory := fakeapi.KafkaInstanceFactory(t, testGroupHandler(nil, nil))
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{KafkaIDField: "test-kafka-id", GroupIDField: "test-group"})
+
+		diags := consumerGroupCreate(context.Background(), d, factory)
+
+		assert.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		assert.Equal(t, "test-kafka-id/test-group", d.Id())
+		assert.Empty(t, d.Get(MembersField))
+	})
+}
+
+func TestConsumerGroupImport(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceConsumerGroup(localizer)
+
+	t.Run("valid id", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		d.SetId("test-kafka-id/orders/processor")
+
+		_, err := consumerGroupImport(context.Background(), d, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-kafka-id", d.Get(KafkaIDField))
+		assert.Equal(t, "orders/processor", d.Get(GroupIDField), "expected the group id to keep its slashes")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		d.SetId("test-group")
+
+		_, err := consumerGroupImport(context.Background(), d, nil)
+
+		assert.Error(t, err)
+	})
+}
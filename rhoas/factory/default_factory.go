@@ -8,9 +8,15 @@ import (
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	kafkamgmtv1errors "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/error"
 	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/accountmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
 	"net/http"
+	"time"
 )
 
 type ServiceStatus = string
@@ -28,16 +34,51 @@ const (
 type DefaultFactory struct {
 	kafkaClient          *kafkamgmtclient.APIClient
 	serviceAccountClient *serviceAccounts.APIClient
+	accountMgmtClient    accountmgmt.API
+	registryMgmtClient   registrymgmt.API
+	connectorMgmtClient  connectormgmt.API
+	smartEventsClient    smarteventsmgmt.API
 	httpClient           *http.Client
+	authURL              string
 	localizer            localize.Localizer
+	pollInterval         time.Duration
+	pollMaxInterval      time.Duration
+	validateReferences   bool
+	references           *referenceCache
 }
 
-func NewDefaultFactory(kafkaClient *kafkamgmtclient.APIClient, serviceAccountClient *serviceAccounts.APIClient, httpClient *http.Client, localizer localize.Localizer) *DefaultFactory {
+// Options are the clients and settings of a DefaultFactory. Clients which are
+// not set are left nil, so that a factory only needs the clients it is used with
+type Options struct {
+	KafkaClient          *kafkamgmtclient.APIClient
+	ServiceAccountClient *serviceAccounts.APIClient
+	AccountMgmtClient    accountmgmt.API
+	RegistryMgmtClient   registrymgmt.API
+	ConnectorMgmtClient  connectormgmt.API
+	SmartEventsClient    smarteventsmgmt.API
+	HTTPClient           *http.Client
+	AuthURL              string
+	Localizer            localize.Localizer
+	PollInterval         time.Duration
+	PollMaxInterval      time.Duration
+	ValidateReferences   bool
+}
+
+func NewDefaultFactory(options Options) *DefaultFactory {
 	return &DefaultFactory{
-		kafkaClient:          kafkaClient,
-		serviceAccountClient: serviceAccountClient,
-		httpClient:           httpClient,
-		localizer:            localizer,
+		kafkaClient:          options.KafkaClient,
+		serviceAccountClient: options.ServiceAccountClient,
+		accountMgmtClient:    options.AccountMgmtClient,
+		registryMgmtClient:   options.RegistryMgmtClient,
+		connectorMgmtClient:  options.ConnectorMgmtClient,
+		smartEventsClient:    options.SmartEventsClient,
+		httpClient:           options.HTTPClient,
+		authURL:              options.AuthURL,
+		localizer:            options.Localizer,
+		pollInterval:         options.PollInterval,
+		pollMaxInterval:      options.PollMaxInterval,
+		validateReferences:   options.ValidateReferences,
+		references:           newReferenceCache(),
 	}
 }
 
@@ -49,6 +90,22 @@ func (f *DefaultFactory) ServiceAccountMgmt() serviceAccounts.ServiceAccountsApi
 	return f.serviceAccountClient.ServiceAccountsApi
 }
 
+func (f *DefaultFactory) AccountMgmt() accountmgmt.API {
+	return f.accountMgmtClient
+}
+
+func (f *DefaultFactory) RegistryMgmt() registrymgmt.API {
+	return f.registryMgmtClient
+}
+
+func (f *DefaultFactory) ConnectorMgmt() connectormgmt.API {
+	return f.connectorMgmtClient
+}
+
+func (f *DefaultFactory) SmartEventsMgmt() smarteventsmgmt.API {
+	return f.smartEventsClient
+}
+
 func (f *DefaultFactory) KafkaAdmin(ctx *context.Context, instanceID string) (*kafkainstanceclient.APIClient, *kafkamgmtclient.KafkaRequest, error) {
 	kafkaAPI := f.KafkaMgmt()
 
@@ -96,10 +153,57 @@ func (f *DefaultFactory) KafkaAdmin(ctx *context.Context, instanceID string) (*k
 	return client, &kafkaInstance, nil
 }
 
+func (f *DefaultFactory) RegistryInstance(ctx *context.Context, registryID string) (registryinstance.API, *registrymgmt.Registry, error) {
+	//nolint
+	registry, resp, err := f.RegistryMgmt().GetRegistry(*ctx, registryID)
+	if apiErr := utils.GetAPIError(f, resp, err); apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	switch registry.Status {
+	case StatusProvisioning, StatusAccepted:
+		err = fmt.Errorf(`Service Registry instance "%v" is not ready yet`, registry.Name)
+		return nil, nil, err
+	case StatusFailed:
+		err = fmt.Errorf(`Service Registry instance "%v" has failed`, registry.Name)
+		return nil, nil, err
+	case StatusDeprovision:
+		err = fmt.Errorf(`Service Registry instance "%v" is being deprovisioned`, registry.Name)
+		return nil, nil, err
+	case StatusDeleting:
+		err = fmt.Errorf(`Service Registry instance "%v" is being deleted`, registry.Name)
+		return nil, nil, err
+	}
+
+	if registry.RegistryURL == "" {
+		err = fmt.Errorf(`registry URL is missing for Service Registry instance "%v"`, registry.Name)
+
+		return nil, nil, err
+	}
+
+	client := registryinstance.NewAPIClient(f.httpClient, registry.RegistryURL)
+
+	return client, &registry, nil
+}
+
 func (f *DefaultFactory) HTTPClient() *http.Client {
 	return f.httpClient
 }
 
+// AuthURL returns the url of the realm of the sso server issuing the access
+// tokens of the apis, e.g. for clients of the kafka instances
+func (f *DefaultFactory) AuthURL() string {
+	return f.authURL
+}
+
 func (f *DefaultFactory) Localizer() localize.Localizer {
 	return f.localizer
 }
+
+func (f *DefaultFactory) PollInterval() time.Duration {
+	return f.pollInterval
+}
+
+func (f *DefaultFactory) PollMaxInterval() time.Duration {
+	return f.pollMaxInterval
+}
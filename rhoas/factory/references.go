@@ -0,0 +1,94 @@
+package factory
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+// referenceCache memoizes the lookups of the kafka instances and service
+// accounts referenced by the resources. The provider is configured for each
+// plan, so the lookups are made at most once per plan
+type referenceCache struct {
+	mu      sync.Mutex
+	lookups map[string]*referenceLookup
+}
+
+// referenceLookup is the result of the lookup of a reference. It is made by
+// one resource at a time when the references of several resources are checked
+// concurrently, and only a lookup which succeeds is kept, whether the reference
+// is found or not, so that a lookup failing e.g. with a timeout is made again
+type referenceLookup struct {
+	mu    sync.Mutex
+	done  bool
+	found bool
+}
+
+func newReferenceCache() *referenceCache {
+	return &referenceCache{
+		lookups: map[string]*referenceLookup{},
+	}
+}
+
+// lookup returns the memoized result of the lookup of the key, calling find
+// until it succeeds
+func (c *referenceCache) lookup(key string, find func() (bool, error)) (bool, error) {
+	c.mu.Lock()
+	l, ok := c.lookups[key]
+	if !ok {
+		l = &referenceLookup{}
+		c.lookups[key] = l
+	}
+	c.mu.Unlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.done {
+		found, err := find()
+		if err != nil {
+			return false, err
+		}
+		l.found, l.done = found, true
+	}
+
+	return l.found, nil
+}
+
+// ValidateReferences returns whether the resources check that the kafka
+// instances and service accounts they reference exist when planned
+func (f *DefaultFactory) ValidateReferences() bool {
+	return f.validateReferences
+}
+
+// KafkaExists returns whether the kafka instance with the given id exists and
+// is accessible, a kafka instance of another organization is not found
+func (f *DefaultFactory) KafkaExists(ctx context.Context, id string) (bool, error) {
+	return f.references.lookup("kafka/"+id, func() (bool, error) {
+		//nolint
+		_, resp, err := f.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+			return false, nil
+		}
+		if apiErr := utils.GetAPIError(f, resp, err); apiErr != nil {
+			return false, apiErr
+		}
+
+		return true, nil
+	})
+}
+
+// ServiceAccountExists returns whether a service account of the organization
+// has the given client id
+func (f *DefaultFactory) ServiceAccountExists(ctx context.Context, clientID string) (bool, error) {
+	return f.references.lookup("service_account/"+clientID, func() (bool, error) {
+		serviceAccounts, resp, err := f.ServiceAccountMgmt().GetServiceAccounts(ctx).ClientId([]string{clientID}).Execute()
+		if apiErr := utils.GetAPIError(f, resp, err); apiErr != nil {
+			return false, apiErr
+		}
+
+		return len(serviceAccounts) > 0, nil
+	})
+}
@@ -0,0 +1,39 @@
+package factory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceCacheLookup(t *testing.T) {
+	cache := newReferenceCache()
+
+	// the first lookup times out, the next ones find the reference
+	results := []error{errors.New("timeout"), nil, nil}
+	calls := 0
+	lookup := func() (bool, error) {
+		err := results[calls]
+		calls++
+		return err == nil, err
+	}
+
+	_, err := cache.lookup("kafka/test-id", lookup)
+	assert.Error(t, err, "expected the failed lookup to be reported")
+
+	found, err := cache.lookup("kafka/test-id", lookup)
+	assert.NoError(t, err, "expected the failed lookup to be made again")
+	assert.True(t, found)
+
+	found, err = cache.lookup("kafka/test-id", lookup)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, calls, "expected the successful lookup to be kept")
+
+	notFound := func() (bool, error) { return false, nil }
+	found, _ = cache.lookup("kafka/other-id", notFound)
+	assert.False(t, found)
+	found, _ = cache.lookup("kafka/other-id", func() (bool, error) { return true, nil })
+	assert.False(t, found, "expected a reference which is not found to be kept")
+}
@@ -0,0 +1,107 @@
+// Package fakeapi serves fakes of the apis called by the provider, so that the
+// resources can be unit tested against a factory whose clients call them. It
+// is only imported by tests
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+)
+
+const (
+	// KafkaID is the id of the ready kafka instance of the fake kafka management api
+	KafkaID = "test-kafka-id"
+
+	// RegistryID is the id of the ready registry of the fake registry management api
+	RegistryID = "test-registry"
+
+	kafkaPath        = "/api/kafkas_mgmt/v1/kafkas/"
+	registryPath     = "/api/serviceregistry_mgmt/v1/registries/"
+	registryBasePath = "/t/" + RegistryID + "/apis/registry/v2"
+)
+
+// WriteJSON writes the json encoding of v as the body of a response with the given status
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// KafkaInstanceFactory returns a factory whose kafka management api only knows
+// the ready KafkaID kafka instance, whose admin api is served by the given
+// handler. The options may set the other clients and settings of the factory
+func KafkaInstanceFactory(t *testing.T, admin http.HandlerFunc, options ...func(*factories.Options)) rhoasAPI.Factory {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc(kafkaPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != kafkaPath+KafkaID {
+			WriteJSON(w, http.StatusNotFound, map[string]string{"code": "KAFKAS-MGMT-7", "reason": "kafka not found"})
+			return
+		}
+
+		kafka := kafkamgmtclient.NewKafkaRequest(KafkaID, "Kafka", "", true, true)
+		kafka.SetName("test-kafka")
+		kafka.SetStatus("ready")
+		kafka.SetBootstrapServerHost("test-kafka.example.com:443")
+		kafka.SetAdminApiServerUrl(server.URL)
+
+		WriteJSON(w, http.StatusOK, kafka)
+	})
+	mux.Handle("/", admin)
+
+	return newFactory(server, append([]func(*factories.Options){func(o *factories.Options) {
+		o.KafkaClient = kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+			HTTPClient: server.Client(),
+			BaseURL:    server.URL,
+		})
+	}}, options...))
+}
+
+// RegistryFactory returns a factory whose registry management api only knows
+// the ready RegistryID registry. The api of the registry is served by the given
+// handler, which gets the paths relative to the v2 api, e.g. /admin/rules
+func RegistryFactory(t *testing.T, registry http.HandlerFunc, options ...func(*factories.Options)) rhoasAPI.Factory {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc(registryPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != registryPath+RegistryID {
+			WriteJSON(w, http.StatusNotFound, map[string]string{"code": "SRS-MGMT-2", "reason": "registry not found"})
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, registrymgmt.Registry{ID: RegistryID, Name: "test", Status: "ready", RegistryURL: server.URL + "/t/" + RegistryID})
+	})
+	mux.Handle(registryBasePath+"/", http.StripPrefix(registryBasePath, registry))
+
+	return newFactory(server, append([]func(*factories.Options){func(o *factories.Options) {
+		o.RegistryMgmtClient = registrymgmt.NewAPIClient(server.Client(), server.URL)
+	}}, options...))
+}
+
+// newFactory returns a factory calling the apis of the server, with the options applied
+func newFactory(server *httptest.Server, options []func(*factories.Options)) rhoasAPI.Factory {
+	localizer, _ := goi18n.New(nil)
+
+	o := factories.Options{
+		HTTPClient: server.Client(),
+		Localizer:  localizer,
+	}
+	for _, option := range options {
+		option(&o)
+	}
+
+	return factories.NewDefaultFactory(o)
+}
@@ -0,0 +1,165 @@
+package rhoas
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/acl"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/kafka"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/serviceaccount"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/topic"
+)
+
+// ProviderTypeName is the name of the provider, prefixing the resource types
+const ProviderTypeName = "rhoas"
+
+// frameworkProvider is the half of the provider built on the plugin framework.
+// Resources are migrated to it one at a time, a migrated resource must be
+// removed from the sdk half as both halves are served by a single mux server
+type frameworkProvider struct {
+	localizer localize.Localizer
+}
+
+// frameworkProviderModel maps the attributes of the provider
+type frameworkProviderModel struct {
+	OfflineToken       types.String `tfsdk:"offline_token"`
+	PollInterval       types.String `tfsdk:"poll_interval"`
+	PollMaxInterval    types.String `tfsdk:"poll_max_interval"`
+	ValidateReferences types.Bool   `tfsdk:"validate_references"`
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+// FrameworkProvider returns the plugin framework half of the provider
+func FrameworkProvider() provider.Provider {
+	localizer, err := goi18n.New(nil)
+	if err != nil {
+		log.Fatalf("unable to load the localized messages: %s", err)
+	}
+
+	return &frameworkProvider{
+		localizer: localizer,
+	}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = ProviderTypeName
+}
+
+// Schema returns the schema of the sdk half, the values are validated by the
+// sdk half which is configured first
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			OfflineTokenField: schema.StringAttribute{
+				Optional:    true,
+				Description: offlineTokenDescription,
+			},
+			PollIntervalField: schema.StringAttribute{
+				Optional:    true,
+				Description: pollIntervalDescription,
+			},
+			PollMaxIntervalField: schema.StringAttribute{
+				Optional:    true,
+				Description: pollMaxIntervalDescription,
+			},
+			ValidateReferencesField: schema.BoolAttribute{
+				Optional:    true,
+				Description: validateReferencesDescription,
+			},
+		},
+	}
+}
+
+// Configure shares the factory of the sdk half with the resources of the
+// plugin framework half
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var model frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateReferences := model.ValidateReferences.ValueBool()
+	if model.ValidateReferences.IsNull() {
+		validateReferences, _ = strconv.ParseBool(os.Getenv(ValidateReferencesEnv))
+	}
+
+	config, err := newProviderConfig(
+		stringValueOrEnv(model.OfflineToken, OfflineTokenEnv, ""),
+		stringValueOrEnv(model.PollInterval, PollIntervalEnv, DefaultPollInterval),
+		stringValueOrEnv(model.PollMaxInterval, PollMaxIntervalEnv, DefaultPollMaxInterval),
+		validateReferences,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(p.localizer.MustLocalize("common.errors.configure"), err.Error())
+		return
+	}
+
+	factory := configureFactory(config, p.localizer)
+
+	resp.ResourceData = factory
+	resp.DataSourceData = factory
+	resp.EphemeralResourceData = factory
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource { return acl.NewResourceACL(p.localizer) },
+	}
+}
+
+// EphemeralResources returns the ephemeral resources, which can only be served
+// by the plugin framework half
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return serviceaccount.NewEphemeralServiceAccountCredentials(p.localizer)
+		},
+	}
+}
+
+// Functions returns the provider functions, which can only be served by the
+// plugin framework half. They are backed by the helpers used by the resources
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function { return acl.NewPrincipalFunction(p.localizer) },
+		func() function.Function { return acl.NewACLIDFunction(p.localizer) },
+		func() function.Function { return topic.NewValidTopicNameFunction(p.localizer) },
+		func() function.Function { return kafka.NewValidKafkaNameFunction(p.localizer) },
+		func() function.Function { return kafka.NewParsePlanFunction(p.localizer) },
+		func() function.Function { return kafka.NewBootstrapURLFunction(p.localizer) },
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// stringValueOrEnv returns the value when set, like schema.EnvDefaultFunc
+// otherwise the value of the environment variable or the default value
+func stringValueOrEnv(value types.String, env string, defaultValue string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+
+	return defaultValue
+}
@@ -6,101 +6,34 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
 )
 
 func DataSourceKafka(localizer localize.Localizer) *schema.Resource {
+	kafkaSchema := dataSourceKafkaSchema(localizer)
+
+	// the kafka instance can be read by either its id or name
+	kafkaSchema[IDField] = &schema.Schema{
+		Description:  localizer.MustLocalize("kafka.datasource.field.description.id"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{IDField, NameField},
+	}
+	kafkaSchema[NameField] = &schema.Schema{
+		Description:  localizer.MustLocalize("kafka.datasource.field.description.name"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{IDField, NameField},
+	}
+
 	return &schema.Resource{
 		Description: "`rhoas_kafka` provides a Kafka accessible to your organization in Red Hat OpenShift Streams for Apache Kafka.",
 		ReadContext: dataSourceKafkaRead,
-		Schema: map[string]*schema.Schema{
-			NameField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.name"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			CloudProviderField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			RegionField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.region"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			ReauthenticationEnabledField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.reauthenticationEnabled"),
-				Type:        schema.TypeBool,
-				Computed:    true,
-			},
-			PlanField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.plan"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			BillingCloudAccountIDField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.billingCloudAccountId"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			MarketPlaceField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.marketplace"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			BillingModelField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.billingModel"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			HrefField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.href"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			StatusField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.status"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			OwnerField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.owner"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			BootstrapServerHostField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.bootstrapServerHost"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			CreatedAtField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.createdAt"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			UpdatedAtField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.updatedAt"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			IDField: {
-				Description: localizer.MustLocalize("kafka.datasource.field.description.id"),
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			KindField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.kind"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			VersionField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.version"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-		},
+		Schema:      kafkaSchema,
 	}
 }
 
@@ -118,15 +51,56 @@ func dataSourceKafkaRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", IDField)))
 	}
 
-	kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, id).Execute()
-	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
-		return diag.FromErr(apiErr)
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField)))
+	}
+
+	var kafka kafkamgmtclient.KafkaRequest
+	var err error
+
+	if id != "" {
+		kafka, err = getKafkaByID(ctx, factory, id)
+	} else {
+		kafka, err = getKafkaByName(ctx, factory, name)
+	}
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	err = setResourceDataFromKafkaData(d, &kafka)
+	d.SetId(kafka.GetId())
+
+	err = setResourceDataFromKafkaData(d, mapKafkaToData(&kafka, OAuthTokenEndpointURI(factory)))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	return diags
 }
+
+func getKafkaByID(ctx context.Context, factory rhoasAPI.Factory, id string) (kafkamgmtclient.KafkaRequest, error) {
+	kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return kafkamgmtclient.KafkaRequest{}, apiErr
+	}
+
+	return kafka, nil
+}
+
+// getKafkaByName looks up the kafka instance with the given name, failing if
+// there is no instance or more than one instance with that name
+func getKafkaByName(ctx context.Context, factory rhoasAPI.Factory, name string) (kafkamgmtclient.KafkaRequest, error) {
+	kafkas, err := listKafkas(ctx, factory, buildKafkasSearchQuery(map[string]string{NameField: name}))
+	if err != nil {
+		return kafkamgmtclient.KafkaRequest{}, err
+	}
+
+	switch len(kafkas) {
+	case 0:
+		return kafkamgmtclient.KafkaRequest{}, factory.Localizer().MustLocalizeError("kafka.errors.notFoundByName", localize.NewEntry("Name", name))
+	case 1:
+		return kafkas[0], nil
+	default:
+		return kafkamgmtclient.KafkaRequest{}, factory.Localizer().MustLocalizeError("kafka.errors.multipleFoundByName", localize.NewEntry("Name", name), localize.NewEntry("Count", len(kafkas)))
+	}
+}
@@ -0,0 +1,329 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	KafkaIDField      = "kafka_id"
+	ClientIDField     = "client_id"
+	ClientSecretField = "client_secret"
+	FormatField       = "format"
+	ContentField      = "content"
+
+	FormatProperties = "properties"
+	FormatLibrdkafka = "librdkafka"
+	FormatQuarkus    = "quarkus"
+	FormatSpring     = "spring"
+	FormatEnv        = "env"
+	FormatJSON       = "json"
+
+	// the login callback handler shipped with the Apache Kafka java client
+	// since 3.1 which fetches access tokens from an OAuth token endpoint
+	oauthLoginCallbackHandler = "org.apache.kafka.common.security.oauthbearer.secured.OAuthBearerLoginCallbackHandler"
+)
+
+// ConnectionFormats are the client configuration formats which can be
+// rendered by the rhoas_kafka_connection data source
+var ConnectionFormats = []string{
+	FormatProperties,
+	FormatLibrdkafka,
+	FormatQuarkus,
+	FormatSpring,
+	FormatEnv,
+	FormatJSON,
+}
+
+// connectionConfig holds everything a kafka client needs to connect to a
+// kafka instance using the credentials of a service account
+type connectionConfig struct {
+	BootstrapServerHost   string
+	ClientID              string
+	ClientSecret          string
+	OAuthTokenEndpointURI string
+}
+
+func DataSourceKafkaConnection(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_kafka_connection` provides the configuration for a Kafka client to connect to a Kafka instance in Red Hat OpenShift Streams for Apache Kafka using a service account.",
+		ReadContext: dataSourceKafkaConnectionRead,
+		Schema: map[string]*schema.Schema{
+			KafkaIDField: {
+				Description: localizer.MustLocalize("kafka.connection.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			ClientIDField: {
+				Description: localizer.MustLocalize("kafka.connection.field.description.clientID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			ClientSecretField: {
+				Description: localizer.MustLocalize("kafka.connection.field.description.clientSecret"),
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			FormatField: {
+				Description:  localizer.MustLocalize("kafka.connection.field.description.format", localize.NewEntry("Formats", strings.Join(ConnectionFormats, ", "))),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      FormatProperties,
+				ValidateFunc: validation.StringInSlice(ConnectionFormats, false),
+			},
+			BootstrapServerHostField: {
+				Description: localizer.MustLocalize("kafka.resource.field.description.bootstrapServerHost"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OAuthTokenEndpointURIField: {
+				Description: localizer.MustLocalize("kafka.resource.field.description.oauthTokenEndpointUri"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			ContentField: {
+				Description: localizer.MustLocalize("kafka.connection.field.description.content"),
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceKafkaConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	clientID, ok := d.Get(ClientIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ClientIDField)))
+	}
+
+	clientSecret, ok := d.Get(ClientSecretField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ClientSecretField)))
+	}
+
+	format, ok := d.Get(FormatField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", FormatField)))
+	}
+
+	kafka, err := getKafkaByID(ctx, factory, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if kafka.GetBootstrapServerHost() == "" {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("kafka.errors.bootstrapServerHostMissing", localize.NewEntry("Name", kafka.GetName()), localize.NewEntry("Status", kafka.GetStatus())))
+	}
+
+	bootstrapURL, err := BootstrapURL(kafka.GetBootstrapServerHost(), 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := connectionConfig{
+		BootstrapServerHost:   bootstrapURL,
+		ClientID:              clientID,
+		ClientSecret:          clientSecret,
+		OAuthTokenEndpointURI: OAuthTokenEndpointURI(factory),
+	}
+
+	content, err := renderConnectionConfig(&config, format)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(BootstrapServerHostField, config.BootstrapServerHost); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(OAuthTokenEndpointURIField, config.OAuthTokenEndpointURI); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(ContentField, content); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", kafkaID, clientID, format))
+
+	return diags
+}
+
+// renderConnectionConfig renders the connection configuration in the given format
+func renderConnectionConfig(config *connectionConfig, format string) (string, error) {
+	switch format {
+	case FormatProperties:
+		return renderProperties(javaClientProperties(config), "", escapeProperty), nil
+	case FormatLibrdkafka:
+		return renderProperties(librdkafkaProperties(config), "", rawProperty), nil
+	case FormatQuarkus:
+		return renderProperties(javaClientProperties(config), "kafka.", escapeProperty), nil
+	case FormatSpring:
+		return renderSpringYAML(config)
+	case FormatEnv:
+		return renderEnv(config), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(javaClientProperties(config), "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	return "", fmt.Errorf("unsupported connection configuration format %q", format)
+}
+
+// javaClientProperties returns the properties used by the Apache Kafka java client
+func javaClientProperties(config *connectionConfig) map[string]string {
+	return map[string]string{
+		"bootstrap.servers":                   config.BootstrapServerHost,
+		"security.protocol":                   "SASL_SSL",
+		"sasl.mechanism":                      "OAUTHBEARER",
+		"sasl.oauthbearer.token.endpoint.url": config.OAuthTokenEndpointURI,
+		"sasl.login.callback.handler.class":   oauthLoginCallbackHandler,
+		"sasl.jaas.config":                    jaasConfig(config),
+	}
+}
+
+// librdkafkaProperties returns the properties used by librdkafka based clients
+func librdkafkaProperties(config *connectionConfig) map[string]string {
+	return map[string]string{
+		"bootstrap.servers":                   config.BootstrapServerHost,
+		"security.protocol":                   "SASL_SSL",
+		"sasl.mechanisms":                     "OAUTHBEARER",
+		"sasl.oauthbearer.method":             "oidc",
+		"sasl.oauthbearer.client.id":          config.ClientID,
+		"sasl.oauthbearer.client.secret":      config.ClientSecret,
+		"sasl.oauthbearer.token.endpoint.url": config.OAuthTokenEndpointURI,
+	}
+}
+
+func jaasConfig(config *connectionConfig) string {
+	return fmt.Sprintf(`org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required clientId="%s" clientSecret="%s";`, escapeJAAS(config.ClientID), escapeJAAS(config.ClientSecret))
+}
+
+// escapeJAAS escapes s to be quoted in a jaas configuration, whose quoted
+// strings are read by a java.io.StreamTokenizer unescaping backslashes
+func escapeJAAS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// escapeProperty escapes the key or value s of a java properties file as
+// java.util.Properties.store does, so that it is read back unchanged
+func escapeProperty(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == ' ' && (key || i == 0):
+			b.WriteString(`\ `)
+		case r == '\\' || r == '=' || r == ':' || r == '#' || r == '!':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r < 0x20 || r > 0x7e:
+			for _, c := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04X`, c)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// rawProperty leaves the key or value s of a librdkafka properties file as is,
+// librdkafka reading the rest of the line as the value without unescaping it
+func rawProperty(s string, key bool) string {
+	return s
+}
+
+// renderProperties renders the properties sorted by key in the java properties
+// format, prefixing every key with the given prefix and escaping the keys and
+// values with the given function
+func renderProperties(properties map[string]string, prefix string, escape func(s string, key bool) string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", escape(prefix+key, true), escape(properties[key], false))
+	}
+
+	return b.String()
+}
+
+// renderSpringYAML renders the spring boot application properties. They are
+// encoded as yaml so that values such as the client secret are quoted as needed
+func renderSpringYAML(config *connectionConfig) (string, error) {
+	properties := yaml.MapSlice{
+		{Key: "spring", Value: yaml.MapSlice{
+			{Key: "kafka", Value: yaml.MapSlice{
+				{Key: "bootstrap-servers", Value: config.BootstrapServerHost},
+				{Key: "security", Value: yaml.MapSlice{
+					{Key: "protocol", Value: "SASL_SSL"},
+				}},
+				{Key: "properties", Value: yaml.MapSlice{
+					{Key: "sasl.mechanism", Value: "OAUTHBEARER"},
+					{Key: "sasl.oauthbearer.token.endpoint.url", Value: config.OAuthTokenEndpointURI},
+					{Key: "sasl.login.callback.handler.class", Value: oauthLoginCallbackHandler},
+					{Key: "sasl.jaas.config", Value: jaasConfig(config)},
+				}},
+			}},
+		}},
+	}
+
+	data, err := yaml.Marshal(properties)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// renderEnv renders the environment variables also generated by the rhoas cli
+func renderEnv(config *connectionConfig) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "KAFKA_HOST=%s\n", config.BootstrapServerHost)
+	fmt.Fprintf(&b, "RHOAS_CLIENT_ID=%s\n", config.ClientID)
+	fmt.Fprintf(&b, "RHOAS_CLIENT_SECRET=%s\n", config.ClientSecret)
+	fmt.Fprintf(&b, "RHOAS_OAUTH_TOKEN_URL=%s\n", config.OAuthTokenEndpointURI)
+
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package kafka
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func testConnectionConfig() *connectionConfig {
+	return &connectionConfig{
+		BootstrapServerHost:   "test-kafka.kafka.example.com:443",
+		ClientID:              "test-client-id",
+		ClientSecret:          "test-client-secret",
+		OAuthTokenEndpointURI: "https://sso.example.com/token",
+	}
+}
+
+// testLoadProperties reads the properties rendered one per line as
+// java.util.Properties.load does, unescaping the keys and values
+func testLoadProperties(t *testing.T, content string) map[string]string {
+	properties := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		var key, value []uint16
+		inValue := false
+		for i := 0; i < len(line); i++ {
+			c := rune(line[i])
+			if c == '\\' && i+1 < len(line) {
+				i++
+				switch c = rune(line[i]); c {
+				case 't':
+					c = '\t'
+				case 'n':
+					c = '\n'
+				case 'r':
+					c = '\r'
+				case 'f':
+					c = '\f'
+				case 'u':
+					u, err := strconv.ParseUint(line[i+1:i+5], 16, 16)
+					assert.NoError(t, err, "unexpected unicode escape in %q", line)
+					c = rune(u)
+					i += 4
+				}
+			} else if !inValue && (c == '=' || c == ':') {
+				inValue = true
+				continue
+			}
+
+			if inValue {
+				value = append(value, uint16(c))
+			} else {
+				key = append(key, uint16(c))
+			}
+		}
+		properties[string(utf16.Decode(key))] = string(utf16.Decode(value))
+	}
+
+	return properties
+}
+
+func TestRenderConnectionConfig(t *testing.T) {
+	config := testConnectionConfig()
+
+	t.Run("every format renders the connection details", func(t *testing.T) {
+		for _, format := range ConnectionFormats {
+			content, err := renderConnectionConfig(config, format)
+			assert.NoError(t, err, "unexpected error rendering %s", format)
+			if format == FormatProperties || format == FormatQuarkus {
+				content = renderProperties(testLoadProperties(t, content), "", rawProperty)
+			}
+			for _, want := range []string{config.BootstrapServerHost, config.ClientID, config.ClientSecret, config.OAuthTokenEndpointURI} {
+				assert.Contains(t, content, want, "expected %s to contain %s", format, want)
+			}
+		}
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatProperties)
+		lines := strings.Split(strings.TrimSpace(content), "\n")
+
+		assert.Equal(t, `bootstrap.servers=test-kafka.kafka.example.com\:443`, lines[0], "expected the properties to be sorted")
+		assert.Contains(t, lines, "sasl.mechanism=OAUTHBEARER")
+
+		properties := testLoadProperties(t, content)
+		assert.Equal(t, javaClientProperties(config), properties)
+		assert.Equal(t, `org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required clientId="test-client-id" clientSecret="test-client-secret";`, properties["sasl.jaas.config"])
+	})
+
+	t.Run("properties escape the keys and values", func(t *testing.T) {
+		escaped := testConnectionConfig()
+		escaped.ClientSecret = " a\"b\\c=d:e #f!g\nh\té"
+
+		content, _ := renderConnectionConfig(escaped, FormatProperties)
+
+		assert.Contains(t, content, `clientSecret\=" a\\"b\\\\c\=d\:e \#f\!g\nh\t\u00E9";`+"\n")
+		assert.Equal(t, javaClientProperties(escaped), testLoadProperties(t, content))
+		assert.Equal(t, map[string]string{"a key": "\\"}, testLoadProperties(t, renderProperties(map[string]string{"a key": "\\"}, "", escapeProperty)))
+	})
+
+	t.Run("jaas config escapes the quoted values", func(t *testing.T) {
+		escaped := testConnectionConfig()
+		escaped.ClientID = `id"`
+		escaped.ClientSecret = `secret\" debug="true`
+
+		assert.Equal(t, `org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required clientId="id\"" clientSecret="secret\\\" debug=\"true";`, jaasConfig(escaped))
+	})
+
+	t.Run("librdkafka", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatLibrdkafka)
+
+		assert.Contains(t, content, "sasl.mechanisms=OAUTHBEARER\n")
+		assert.Contains(t, content, "sasl.oauthbearer.method=oidc\n")
+		assert.Contains(t, content, "sasl.oauthbearer.client.id=test-client-id\n")
+	})
+
+	t.Run("librdkafka leaves the values unescaped", func(t *testing.T) {
+		raw := testConnectionConfig()
+		raw.ClientSecret = `a"b\c=d:e`
+
+		content, _ := renderConnectionConfig(raw, FormatLibrdkafka)
+
+		assert.Contains(t, content, "sasl.oauthbearer.client.secret=a\"b\\c=d:e\n")
+	})
+
+	t.Run("quarkus prefixes every property", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatQuarkus)
+
+		for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+			assert.True(t, strings.HasPrefix(line, "kafka."), "expected %q to be prefixed", line)
+		}
+		assert.Equal(t, javaClientProperties(config)["sasl.jaas.config"], testLoadProperties(t, content)["kafka.sasl.jaas.config"])
+	})
+
+	t.Run("spring", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatSpring)
+
+		assert.True(t, strings.HasPrefix(content, "spring:\n  kafka:\n"))
+		assert.Contains(t, content, "    bootstrap-servers: test-kafka.kafka.example.com:443\n")
+	})
+
+	t.Run("spring quotes the client secret", func(t *testing.T) {
+		quoted := testConnectionConfig()
+		quoted.ClientSecret = "it's: #secret"
+
+		content, err := renderConnectionConfig(quoted, FormatSpring)
+		assert.NoError(t, err)
+
+		var properties struct {
+			Spring struct {
+				Kafka struct {
+					Properties map[string]string `yaml:"properties"`
+				} `yaml:"kafka"`
+			} `yaml:"spring"`
+		}
+		assert.NoError(t, yaml.Unmarshal([]byte(content), &properties), "expected valid yaml")
+		assert.Equal(t, jaasConfig(quoted), properties.Spring.Kafka.Properties["sasl.jaas.config"])
+	})
+
+	t.Run("env", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatEnv)
+
+		assert.Equal(t, "KAFKA_HOST=test-kafka.kafka.example.com:443\nRHOAS_CLIENT_ID=test-client-id\nRHOAS_CLIENT_SECRET=test-client-secret\nRHOAS_OAUTH_TOKEN_URL=https://sso.example.com/token\n", content)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		content, _ := renderConnectionConfig(config, FormatJSON)
+
+		properties := map[string]string{}
+		assert.NoError(t, json.Unmarshal([]byte(content), &properties))
+		assert.Equal(t, javaClientProperties(config), properties)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := renderConnectionConfig(config, "xml")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,382 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	FiltersField   = "filters"
+	DurationField  = "duration"
+	IntervalField  = "interval"
+	SeriesField    = "series"
+	MetricField    = "metric"
+	LabelsField    = "labels"
+	ValuesField    = "values"
+	TimestampField = "timestamp"
+	ValueField     = "value"
+
+	DiskUsedBytesField         = "disk_used_bytes"
+	StorageLimitBytesField     = "storage_limit_bytes"
+	PartitionsField            = "partitions"
+	PartitionLimitField        = "partition_limit"
+	ConnectionsField           = "connections"
+	ConnectionLimitField       = "connection_limit"
+	IngressBytesPerSecondField = "ingress_bytes_per_second"
+	EgressBytesPerSecondField  = "egress_bytes_per_second"
+
+	// the label holding the name of a metric
+	metricNameLabel = "__name__"
+
+	// the bounds of a range query accepted by the metrics api, which takes the
+	// duration in minutes and the interval in seconds
+	maxMetricsDuration = 72 * time.Hour
+	maxMetricsInterval = 3 * time.Hour
+)
+
+// metricsSummary is a field of the rhoas_kafka_metrics data source which
+// summarizes the latest value of a metric across all of its series
+type metricsSummary struct {
+	metric      string
+	description string
+}
+
+var metricsSummaryFields = map[string]metricsSummary{
+	DiskUsedBytesField:         {"kubelet_volume_stats_used_bytes", "kafka.metrics.field.description.diskUsedBytes"},
+	StorageLimitBytesField:     {"kafka_broker_quota_softlimitbytes", "kafka.metrics.field.description.storageLimitBytes"},
+	PartitionsField:            {"kafka_topic:kafka_topic_partitions:sum", "kafka.metrics.field.description.partitions"},
+	PartitionLimitField:        {"kafka_instance_partition_limit", "kafka.metrics.field.description.partitionLimit"},
+	ConnectionsField:           {"kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum", "kafka.metrics.field.description.connections"},
+	ConnectionLimitField:       {"kafka_instance_connection_limit", "kafka.metrics.field.description.connectionLimit"},
+	IngressBytesPerSecondField: {"kafka_namespace:haproxy_server_bytes_in_total:rate5m", "kafka.metrics.field.description.ingressBytesPerSecond"},
+	EgressBytesPerSecondField:  {"kafka_namespace:haproxy_server_bytes_out_total:rate5m", "kafka.metrics.field.description.egressBytesPerSecond"},
+}
+
+// metricsSeries is a time series returned by either an instant or a range query
+type metricsSeries struct {
+	Metric string
+	Labels map[string]string
+	Values []kafkamgmtclient.Values
+}
+
+// nolint:funlen
+func DataSourceKafkaMetrics(localizer localize.Localizer) *schema.Resource {
+	metricsSchema := map[string]*schema.Schema{
+		KafkaIDField: {
+			Description: localizer.MustLocalize("kafka.metrics.field.description.kafkaID"),
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		FiltersField: {
+			Description: localizer.MustLocalize("kafka.metrics.field.description.filters"),
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		DurationField: {
+			Description:      localizer.MustLocalize("kafka.metrics.field.description.duration"),
+			Type:             schema.TypeString,
+			Optional:         true,
+			RequiredWith:     []string{IntervalField},
+			ValidateDiagFunc: validateMetricsDuration(time.Minute, "minutes", maxMetricsDuration),
+		},
+		IntervalField: {
+			Description:      localizer.MustLocalize("kafka.metrics.field.description.interval"),
+			Type:             schema.TypeString,
+			Optional:         true,
+			RequiredWith:     []string{DurationField},
+			ValidateDiagFunc: validateMetricsDuration(time.Second, "seconds", maxMetricsInterval),
+		},
+		SeriesField: {
+			Description: localizer.MustLocalize("kafka.metrics.field.description.series"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					MetricField: {
+						Description: localizer.MustLocalize("kafka.metrics.field.description.metric"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					LabelsField: {
+						Description: localizer.MustLocalize("kafka.metrics.field.description.labels"),
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					ValuesField: {
+						Description: localizer.MustLocalize("kafka.metrics.field.description.values"),
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								TimestampField: {
+									Description: localizer.MustLocalize("kafka.metrics.field.description.timestamp"),
+									Type:        schema.TypeString,
+									Computed:    true,
+								},
+								ValueField: {
+									Description: localizer.MustLocalize("kafka.metrics.field.description.value"),
+									Type:        schema.TypeFloat,
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for field, summary := range metricsSummaryFields {
+		metricsSchema[field] = &schema.Schema{
+			Description: localizer.MustLocalize(summary.description, localize.NewEntry("Metric", summary.metric)),
+			Type:        schema.TypeFloat,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "`rhoas_kafka_metrics` provides the metrics of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, either at the current instant or over a range of time.",
+		ReadContext: dataSourceKafkaMetricsRead,
+		Schema:      metricsSchema,
+	}
+}
+
+func dataSourceKafkaMetricsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	filtersInput, ok := d.Get(FiltersField).([]interface{})
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", FiltersField)))
+	}
+
+	filters := make([]string, len(filtersInput))
+	for i, filter := range filtersInput {
+		filters[i], _ = filter.(string)
+	}
+
+	duration, ok := d.Get(DurationField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", DurationField)))
+	}
+
+	interval, ok := d.Get(IntervalField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", IntervalField)))
+	}
+
+	var series []metricsSeries
+	var err error
+	if duration == "" {
+		series, err = queryInstantMetrics(ctx, factory, kafkaID, filters)
+	} else {
+		series, err = queryRangeMetrics(ctx, factory, kafkaID, filters, duration, interval)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(SeriesField, flattenMetricsSeries(series)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for field, value := range summarizeMetrics(series) {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(kafkaID)
+
+	return diags
+}
+
+func queryInstantMetrics(ctx context.Context, factory rhoasAPI.Factory, kafkaID string, filters []string) ([]metricsSeries, error) {
+	request := factory.KafkaMgmt().GetMetricsByInstantQuery(ctx, kafkaID)
+	if len(filters) > 0 {
+		request = request.Filters(filters)
+	}
+
+	list, resp, err := request.Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	series := make([]metricsSeries, len(list.GetItems()))
+	for i, item := range list.GetItems() {
+		value := kafkamgmtclient.NewValues(item.GetValue())
+		if item.HasTimestamp() {
+			value.SetTimestamp(item.GetTimestamp())
+		}
+
+		series[i] = newMetricsSeries(item.GetMetric(), []kafkamgmtclient.Values{*value})
+	}
+
+	return series, nil
+}
+
+func queryRangeMetrics(ctx context.Context, factory rhoasAPI.Factory, kafkaID string, filters []string, duration string, interval string) ([]metricsSeries, error) {
+	// both durations are validated by the schema
+	durationValue, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, err
+	}
+
+	intervalValue, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	request := factory.KafkaMgmt().GetMetricsByRangeQuery(ctx, kafkaID).
+		Duration(int64(durationValue / time.Minute)).
+		Interval(int64(intervalValue / time.Second))
+	if len(filters) > 0 {
+		request = request.Filters(filters)
+	}
+
+	list, resp, err := request.Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	series := make([]metricsSeries, len(list.GetItems()))
+	for i, item := range list.GetItems() {
+		series[i] = newMetricsSeries(item.GetMetric(), item.GetValues())
+	}
+
+	return series, nil
+}
+
+// newMetricsSeries splits the name of the metric from its other labels
+func newMetricsSeries(metric map[string]string, values []kafkamgmtclient.Values) metricsSeries {
+	labels := make(map[string]string, len(metric))
+	for name, value := range metric {
+		if name != metricNameLabel {
+			labels[name] = value
+		}
+	}
+
+	return metricsSeries{
+		Metric: metric[metricNameLabel],
+		Labels: labels,
+		Values: values,
+	}
+}
+
+// summarizeMetrics returns the value of every summary field, which is the sum
+// of the latest values of all of the series of its metric, e.g. the disk used
+// by all brokers of the instance
+func summarizeMetrics(series []metricsSeries) map[string]float64 {
+	summary := make(map[string]float64, len(metricsSummaryFields))
+
+	for field, metric := range metricsSummaryFields {
+		summary[field] = 0
+		for _, s := range series {
+			if s.Metric != metric.metric || len(s.Values) == 0 {
+				continue
+			}
+
+			latest := s.Values[0]
+			for _, value := range s.Values[1:] {
+				if value.GetTimestamp() > latest.GetTimestamp() {
+					latest = value
+				}
+			}
+
+			summary[field] += latest.GetValue()
+		}
+	}
+
+	return summary
+}
+
+// flattenMetricsSeries maps the series sorted by metric and labels so the
+// order does not change between reads
+func flattenMetricsSeries(series []metricsSeries) []interface{} {
+	sorted := make([]metricsSeries, len(series))
+	copy(sorted, series)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Metric != sorted[j].Metric {
+			return sorted[i].Metric < sorted[j].Metric
+		}
+		return fmt.Sprint(sorted[i].Labels) < fmt.Sprint(sorted[j].Labels)
+	})
+
+	ss := make([]interface{}, len(sorted))
+	for i, s := range sorted {
+		labels := make(map[string]interface{}, len(s.Labels))
+		for name, value := range s.Labels {
+			labels[name] = value
+		}
+
+		values := make([]interface{}, len(s.Values))
+		for j, value := range s.Values {
+			timestamp := ""
+			if value.HasTimestamp() {
+				// the metrics api returns timestamps in milliseconds
+				timestamp = time.UnixMilli(value.GetTimestamp()).UTC().Format(time.RFC3339)
+			}
+
+			values[j] = map[string]interface{}{
+				TimestampField: timestamp,
+				ValueField:     value.GetValue(),
+			}
+		}
+
+		ss[i] = map[string]interface{}{
+			MetricField: s.Metric,
+			LabelsField: labels,
+			ValuesField: values,
+		}
+	}
+
+	return ss
+}
+
+// validateMetricsDuration validates a duration accepted by the metrics api,
+// which must be a positive whole number of the given unit up to the given max
+func validateMetricsDuration(unit time.Duration, unitName string, max time.Duration) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := v.(string)
+		if !ok {
+			return diag.Errorf("expected a string, got %T", v)
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if duration < unit || duration > max || duration%unit != 0 {
+			return diag.Errorf("expected a whole number of %s of at most %s, got %s", unitName, max, value)
+		}
+
+		return nil
+	}
+}
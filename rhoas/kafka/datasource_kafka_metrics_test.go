@@ -0,0 +1,110 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func testValues(timestamp int64, value float64) kafkamgmtclient.Values {
+	v := kafkamgmtclient.NewValues(value)
+	v.SetTimestamp(timestamp)
+	return *v
+}
+
+func TestSummarizeMetrics(t *testing.T) {
+	series := []metricsSeries{
+		newMetricsSeries(map[string]string{metricNameLabel: "kubelet_volume_stats_used_bytes", "persistentvolumeclaim": "broker-0"}, []kafkamgmtclient.Values{testValues(2, 20), testValues(1, 10)}),
+		newMetricsSeries(map[string]string{metricNameLabel: "kubelet_volume_stats_used_bytes", "persistentvolumeclaim": "broker-1"}, []kafkamgmtclient.Values{testValues(1, 5), testValues(2, 7)}),
+		newMetricsSeries(map[string]string{metricNameLabel: "kafka_instance_partition_limit"}, []kafkamgmtclient.Values{testValues(1, 1000)}),
+		newMetricsSeries(map[string]string{metricNameLabel: "kafka_instance_connection_limit"}, nil),
+	}
+
+	summary := summarizeMetrics(series)
+
+	assert.Equal(t, 27.0, summary[DiskUsedBytesField])
+	assert.Equal(t, 1000.0, summary[PartitionLimitField])
+	assert.Equal(t, 0.0, summary[ConnectionLimitField])
+	assert.Equal(t, 0.0, summary[PartitionsField])
+	assert.Len(t, summary, len(metricsSummaryFields))
+}
+
+func TestValidateMetricsDuration(t *testing.T) {
+	validate := validateMetricsDuration(time.Minute, "minutes", maxMetricsDuration)
+
+	for _, valid := range []string{"1m", "90m", "72h"} {
+		assert.False(t, validate(valid, cty.Path{}).HasError(), valid)
+	}
+
+	for _, invalid := range []string{"", "30s", "90s", "73h", "-1m", "one hour"} {
+		assert.True(t, validate(invalid, cty.Path{}).HasError(), invalid)
+	}
+}
+
+func TestDataSourceKafkaMetricsRead(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/kafkas_mgmt/v1/kafkas/test-kafka-id/metrics/query_range" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+
+		item := kafkamgmtclient.NewRangeQuery()
+		item.SetMetric(map[string]string{metricNameLabel: "kafka_topic:kafka_topic_partitions:sum", "topic": "orders"})
+		item.SetValues([]kafkamgmtclient.Values{testValues(1640995200000, 3)})
+
+		list := kafkamgmtclient.NewMetricsRangeQueryList()
+		list.SetItems([]kafkamgmtclient.RangeQuery{*item})
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+	factory := factories.NewDefaultFactory(factories.Options{
+		KafkaClient: kafkaClient,
+		HTTPClient:  server.Client(),
+		Localizer:   localizer,
+	})
+
+	r := DataSourceKafkaMetrics(localizer)
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		KafkaIDField:  "test-kafka-id",
+		FiltersField:  []interface{}{"kafka_topic:kafka_topic_partitions:sum"},
+		DurationField: "2h",
+		IntervalField: "5m",
+	})
+
+	diags := r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "duration=120&filters=kafka_topic%3Akafka_topic_partitions%3Asum&interval=300", query)
+	assert.Equal(t, 3.0, d.Get(PartitionsField))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			MetricField: "kafka_topic:kafka_topic_partitions:sum",
+			LabelsField: map[string]interface{}{"topic": "orders"},
+			ValuesField: []interface{}{
+				map[string]interface{}{TimestampField: "2022-01-01T00:00:00Z", ValueField: 3.0},
+			},
+		},
+	}, d.Get(SeriesField))
+}
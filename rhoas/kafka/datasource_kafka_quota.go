@@ -0,0 +1,300 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/accountmgmt"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	OrganizationIDField       = "organization_id"
+	QuotasField               = "quotas"
+	QuotaIDField              = "quota_id"
+	ProductField              = "product"
+	AllowedField              = "allowed"
+	ConsumedField             = "consumed"
+	RemainingField            = "remaining"
+	CloudAccountsField        = "cloud_accounts"
+	CloudAccountIDField       = "cloud_account_id"
+	AllowedBillingModelsField = "allowed_billing_models"
+
+	// the name of the resource consuming the quotas of kafka instances
+	kafkaQuotaResourceName = "rhosak"
+)
+
+// kafkaQuotaProducts are the products of the quotas consumed by kafka instances
+var kafkaQuotaProducts = map[string]bool{
+	"RHOSAK":      true,
+	"RHOSAKTrial": true,
+	"RHOSAKEval":  true,
+	"RHOSAKCC":    true,
+}
+
+// kafkaQuota is the streaming unit quota of an organization for a billing model
+type kafkaQuota struct {
+	QuotaID       string
+	Product       string
+	BillingModel  string
+	Allowed       int
+	Consumed      int
+	CloudAccounts []accountmgmt.CloudAccount
+}
+
+func (q *kafkaQuota) Remaining() int {
+	if q.Consumed >= q.Allowed {
+		return 0
+	}
+
+	return q.Allowed - q.Consumed
+}
+
+// nolint:funlen
+func DataSourceKafkaQuota(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_kafka_quota` provides the remaining quota of your organization for Kafka instances in Red Hat OpenShift Streams for Apache Kafka.",
+		ReadContext: dataSourceKafkaQuotaRead,
+		Schema: map[string]*schema.Schema{
+			OrganizationIDField: {
+				Description: localizer.MustLocalize("kafka.quota.field.description.organizationID"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			AllowedBillingModelsField: {
+				Description: localizer.MustLocalize("kafka.quota.field.description.allowedBillingModels"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			QuotasField: {
+				Description: localizer.MustLocalize("kafka.quota.field.description.quotas"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						QuotaIDField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.quotaID"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ProductField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.product"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						BillingModelField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.billingModel"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						AllowedField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.allowed"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						ConsumedField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.consumed"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						RemainingField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.remaining"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						CloudAccountsField: {
+							Description: localizer.MustLocalize("kafka.quota.field.description.cloudAccounts"),
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									CloudAccountIDField: {
+										Description: localizer.MustLocalize("kafka.quota.field.description.cloudAccountID"),
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									MarketPlaceField: {
+										Description: localizer.MustLocalize("kafka.quota.field.description.marketplace"),
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	organizationID, quotas, err := getKafkaQuotas(ctx, factory)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(OrganizationIDField, organizationID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(AllowedBillingModelsField, allowedBillingModels(quotas)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(QuotasField, flattenKafkaQuotas(quotas)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationID)
+
+	return diags
+}
+
+// getKafkaQuotas returns the id of the organization of the authenticated user
+// and its quotas for kafka instances, one for each billing model of a quota
+func getKafkaQuotas(ctx context.Context, factory rhoasAPI.Factory) (string, []kafkaQuota, error) {
+	account, resp, err := factory.AccountMgmt().GetCurrentAccount(ctx)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return "", nil, apiErr
+	}
+
+	organizationID := account.Organization.ID
+
+	quotaCosts, resp, err := factory.AccountMgmt().ListQuotaCosts(ctx, organizationID)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return "", nil, apiErr
+	}
+
+	return organizationID, mapQuotaCostsToKafkaQuotas(quotaCosts), nil
+}
+
+func mapQuotaCostsToKafkaQuotas(quotaCosts []accountmgmt.QuotaCost) []kafkaQuota {
+	quotas := make([]kafkaQuota, 0)
+
+	for _, quotaCost := range quotaCosts {
+		// a quota is listed once for every billing model it can be consumed with
+		billingModels := map[string]bool{}
+
+		for _, resource := range quotaCost.RelatedResources {
+			if resource.ResourceName != kafkaQuotaResourceName || !kafkaQuotaProducts[resource.Product] || billingModels[resource.BillingModel] {
+				continue
+			}
+			billingModels[resource.BillingModel] = true
+
+			quotas = append(quotas, kafkaQuota{
+				QuotaID:       quotaCost.QuotaID,
+				Product:       resource.Product,
+				BillingModel:  resource.BillingModel,
+				Allowed:       quotaCost.Allowed,
+				Consumed:      quotaCost.Consumed,
+				CloudAccounts: quotaCost.CloudAccounts,
+			})
+		}
+	}
+
+	return quotas
+}
+
+// allowedBillingModels returns the sorted billing models which have quota left
+func allowedBillingModels(quotas []kafkaQuota) []string {
+	allowed := map[string]bool{}
+	for i := range quotas {
+		if quotas[i].Remaining() > 0 {
+			allowed[quotas[i].BillingModel] = true
+		}
+	}
+
+	billingModels := make([]string, 0, len(allowed))
+	for billingModel := range allowed {
+		billingModels = append(billingModels, billingModel)
+	}
+	sort.Strings(billingModels)
+
+	return billingModels
+}
+
+func flattenKafkaQuotas(quotas []kafkaQuota) []interface{} {
+	qs := make([]interface{}, len(quotas))
+
+	for i := range quotas {
+		cloudAccounts := make([]interface{}, len(quotas[i].CloudAccounts))
+		for j, cloudAccount := range quotas[i].CloudAccounts {
+			cloudAccounts[j] = map[string]interface{}{
+				CloudAccountIDField: cloudAccount.CloudAccountID,
+				MarketPlaceField:    cloudAccount.CloudProviderID,
+			}
+		}
+
+		qs[i] = map[string]interface{}{
+			QuotaIDField:       quotas[i].QuotaID,
+			ProductField:       quotas[i].Product,
+			BillingModelField:  quotas[i].BillingModel,
+			AllowedField:       quotas[i].Allowed,
+			ConsumedField:      quotas[i].Consumed,
+			RemainingField:     quotas[i].Remaining(),
+			CloudAccountsField: cloudAccounts,
+		}
+	}
+
+	return qs
+}
+
+// validateKafkaBilling returns an error when every quota of the organization
+// for the billing model of the kafka instance has fewer streaming units left
+// than the instance consumes, or when its billing cloud account cannot be billed
+// for a quota with enough units left. Without any quota for the billing model
+// the quotas are not known to apply, so the api decides whether the instance
+// can be created
+func validateKafkaBilling(factory rhoasAPI.Factory, quotas []kafkaQuota, units int, billingModel string, billingCloudAccountID string, marketplace string) error {
+	var matching bool
+	var remaining bool
+	var billable bool
+
+	for i := range quotas {
+		if quotas[i].BillingModel != billingModel {
+			continue
+		}
+		matching = true
+
+		if quotas[i].Remaining() < units {
+			continue
+		}
+		remaining = true
+
+		for _, cloudAccount := range quotas[i].CloudAccounts {
+			if cloudAccount.CloudAccountID == billingCloudAccountID && (marketplace == "" || cloudAccount.CloudProviderID == marketplace) {
+				billable = true
+			}
+		}
+	}
+
+	if !matching {
+		return nil
+	}
+
+	if !remaining {
+		return factory.Localizer().MustLocalizeError("kafka.errors.noQuota", localize.NewEntry("Units", units), localize.NewEntry("BillingModel", billingModel))
+	}
+
+	if billingCloudAccountID != DefaultEmptyField && !billable {
+		return factory.Localizer().MustLocalizeError("kafka.errors.billingCloudAccountNotFound", localize.NewEntry("BillingCloudAccountID", billingCloudAccountID), localize.NewEntry("BillingModel", billingModel))
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/accountmgmt"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAccountMgmtFactory returns a factory whose account management api is a
+// local fake serving the given quota costs for the organization "test-org". Its
+// kafka management api offers standard x1 to x3 in us-east-1 of aws
+func testAccountMgmtFactory(t *testing.T, quotaCosts []accountmgmt.QuotaCost) rhoasAPI.Factory {
+	localizer, _ := goi18n.New(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v interface{}
+		switch r.URL.Path {
+		case "/api/accounts_mgmt/v1/current_account":
+			v = accountmgmt.Account{ID: "test-account", Organization: accountmgmt.Organization{ID: "test-org"}}
+		case "/api/accounts_mgmt/v1/organizations/test-org/quota_cost":
+			v = accountmgmt.QuotaCostList{Page: 1, Size: len(quotaCosts), Total: len(quotaCosts), Items: quotaCosts}
+		case "/api/kafkas_mgmt/v1/instance_types/aws/us-east-1":
+			list := kafkamgmtclient.NewSupportedKafkaInstanceTypesList()
+			list.SetInstanceTypes(testInstanceTypes(map[string][]string{"standard": {"x1", "x2", "x3"}}))
+			v = list
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			v = map[string]string{"code": "ACCT-MGMT-7", "reason": "not found"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}))
+	t.Cleanup(server.Close)
+
+	return factories.NewDefaultFactory(factories.Options{
+		AccountMgmtClient: accountmgmt.NewAPIClient(server.Client(), server.URL),
+		KafkaClient: kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+			HTTPClient: server.Client(),
+			BaseURL:    server.URL,
+		}),
+		HTTPClient: server.Client(),
+		Localizer:  localizer,
+	})
+}
+
+var testQuotaCosts = []accountmgmt.QuotaCost{
+	{
+		QuotaID:  "cluster|rhinfra|rhosak|marketplace",
+		Allowed:  10,
+		Consumed: 4,
+		RelatedResources: []accountmgmt.RelatedResource{
+			{ResourceName: "rhosak", Product: "RHOSAK", BillingModel: "marketplace"},
+			{ResourceName: "rhosak", Product: "RHOSAK", BillingModel: "marketplace"},
+			{ResourceName: "rhosak", Product: "RHOSAK", BillingModel: "standard"},
+		},
+		CloudAccounts: []accountmgmt.CloudAccount{
+			{CloudAccountID: "123456789012", CloudProviderID: "aws"},
+		},
+	},
+	{
+		QuotaID:  "cluster|rhinfra|rhosak|eval",
+		Allowed:  1,
+		Consumed: 1,
+		RelatedResources: []accountmgmt.RelatedResource{
+			{ResourceName: "rhosak", Product: "RHOSAKEval", BillingModel: "eval"},
+		},
+	},
+	{
+		QuotaID:  "cluster|rhinfra|osd|standard",
+		Allowed:  5,
+		Consumed: 0,
+		RelatedResources: []accountmgmt.RelatedResource{
+			{ResourceName: "gp.small", Product: "OSD", BillingModel: "standard"},
+		},
+	},
+}
+
+func TestDataSourceKafkaQuotaRead(t *testing.T) {
+	factory := testAccountMgmtFactory(t, testQuotaCosts)
+
+	r := DataSourceKafkaQuota(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+
+	diags := r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "test-org", d.Id())
+	assert.Equal(t, []interface{}{"marketplace", "standard"}, d.Get(AllowedBillingModelsField))
+
+	quotas := d.Get(QuotasField).([]interface{})
+	assert.Len(t, quotas, 3)
+	assert.Equal(t, map[string]interface{}{
+		QuotaIDField:      "cluster|rhinfra|rhosak|marketplace",
+		ProductField:      "RHOSAK",
+		BillingModelField: "marketplace",
+		AllowedField:      10,
+		ConsumedField:     4,
+		RemainingField:    6,
+		CloudAccountsField: []interface{}{
+			map[string]interface{}{CloudAccountIDField: "123456789012", MarketPlaceField: "aws"},
+		},
+	}, quotas[0])
+	assert.Equal(t, 0, quotas[2].(map[string]interface{})[RemainingField])
+}
+
+func TestValidateKafkaBilling(t *testing.T) {
+	factory := testAccountMgmtFactory(t, nil)
+	quotas := mapQuotaCostsToKafkaQuotas(testQuotaCosts)
+
+	tests := []struct {
+		name                  string
+		units                 int
+		billingModel          string
+		billingCloudAccountID string
+		marketplace           string
+		wantErr               bool
+	}{
+		{name: "standard", units: 1, billingModel: "standard"},
+		{name: "units left", units: 6, billingModel: "standard"},
+		{name: "fewer units left", units: 7, billingModel: "standard", wantErr: true},
+		{name: "marketplace account", units: 2, billingModel: "marketplace", billingCloudAccountID: "123456789012", marketplace: "aws"},
+		{name: "unknown marketplace account", units: 1, billingModel: "marketplace", billingCloudAccountID: "000000000000", wantErr: true},
+		{name: "account of another marketplace", units: 1, billingModel: "marketplace", billingCloudAccountID: "123456789012", marketplace: "rhm", wantErr: true},
+		{name: "quota consumed", units: 1, billingModel: "eval", wantErr: true},
+		{name: "no quota for the billing model", units: 1, billingModel: "enterprise"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateKafkaBilling(factory, quotas, tt.units, tt.billingModel, tt.billingCloudAccountID, tt.marketplace) // nolint:scopelint
+			assert.Equal(t, tt.wantErr, err != nil, err)                                                                      // nolint:scopelint
+		})
+	}
+}
+
+func TestCheckKafkaQuota(t *testing.T) {
+	factory := testAccountMgmtFactory(t, testQuotaCosts)
+
+	tests := []struct {
+		name         string
+		plan         string
+		billingModel string
+		wantErr      bool
+	}{
+		{name: "units left", plan: "standard.x3", billingModel: "standard"},
+		{name: "quota consumed", plan: "standard.x1", billingModel: "eval", wantErr: true},
+		{name: "size not offered", plan: "standard.x4", billingModel: "eval"},
+		{name: "no quota for the billing model", plan: "standard.x1", billingModel: "enterprise"},
+		{name: "developer instance", plan: "developer.x1", billingModel: "eval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := kafkamgmtclient.NewKafkaRequestPayload("test-kafka")
+			payload.SetCloudProvider("aws")
+			payload.SetRegion("us-east-1")
+			payload.SetPlan(tt.plan)                 // nolint:scopelint
+			payload.SetBillingModel(tt.billingModel) // nolint:scopelint
+
+			err := checkKafkaQuota(context.Background(), factory, payload)
+			assert.Equal(t, tt.wantErr, err != nil, err) // nolint:scopelint
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+func DataSourceKafkaReady(localizer localize.Localizer) *schema.Resource {
+	kafkaSchema := dataSourceKafkaSchema(localizer)
+
+	kafkaSchema[KafkaIDField] = &schema.Schema{
+		Description: localizer.MustLocalize("kafka.ready.field.description.kafkaID"),
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	return &schema.Resource{
+		Description: "`rhoas_kafka_ready` waits until a Kafka instance in Red Hat OpenShift Streams for Apache Kafka is ready, e.g. when it was created with `wait_for_ready = false`.",
+		ReadContext: dataSourceKafkaReadyRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: kafkaSchema,
+	}
+}
+
+func dataSourceKafkaReadyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	kafka, err := waitForKafkaReady(ctx, factory, kafkaID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(kafka.GetId())
+
+	err = setResourceDataFromKafkaData(d, mapKafkaToData(kafka, OAuthTokenEndpointURI(factory)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
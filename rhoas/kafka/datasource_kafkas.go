@@ -2,8 +2,11 @@ package kafka
 
 import (
 	"context"
+	"fmt"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -13,6 +16,23 @@ import (
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
 )
 
+const (
+	KafkasField = "kafkas"
+
+	// the number of kafka instances requested for each page of the list api
+	kafkasPageSize = 100
+)
+
+// kafkasFilterFields are the fields of the rhoas_kafkas data source which
+// can be used to filter the list of kafka instances
+var kafkasFilterFields = []string{
+	NameField,
+	OwnerField,
+	StatusField,
+	CloudProviderField,
+	RegionField,
+}
+
 func DataSourceKafkas(localizer localize.Localizer) *schema.Resource {
 	return &schema.Resource{
 		Description: "`rhoas_kafkas` provides a list of the Kafkas accessible to your organization in Red Hat OpenShift Streams for Apache Kafka.",
@@ -23,74 +43,39 @@ func DataSourceKafkas(localizer localize.Localizer) *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
+				Deprecated:  localizer.MustLocalize("kafka.datasource.field.deprecated.id"),
+			},
+			NameField: {
+				Description: localizer.MustLocalize("kafka.datasource.field.description.filterName"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			OwnerField: {
+				Description: localizer.MustLocalize("kafka.datasource.field.description.filterOwner"),
+				Type:        schema.TypeString,
+				Optional:    true,
 			},
-			"kafkas": {
+			StatusField: {
+				Description: localizer.MustLocalize("kafka.datasource.field.description.filterStatus"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			CloudProviderField: {
+				Description: localizer.MustLocalize("kafka.datasource.field.description.filterCloudProvider"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			RegionField: {
+				Description: localizer.MustLocalize("kafka.datasource.field.description.filterRegion"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			KafkasField: {
 				Description: "The list of Kafka instances",
 				Type:        schema.TypeList,
 				Computed:    true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						CloudProviderField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						RegionField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.region"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						NameField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.name"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						HrefField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.href"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						StatusField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.status"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						OwnerField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.owner"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						BootstrapServerHostField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.bootstrapServerHost"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						CreatedAtField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.createdAt"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						UpdatedAtField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.updatedAt"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						IDField: {
-							Description: localizer.MustLocalize("kafka.datasource.field.description.id"),
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						KindField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.kind"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						VersionField: {
-							Description: localizer.MustLocalize("kafka.resource.field.description.version"),
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-					},
+					Schema: dataSourceKafkaSchema(localizer),
 				},
 			},
 		},
@@ -112,12 +97,22 @@ func dataSourceKafkasRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("unable to cast %v to string", val)
 	}
 
-	kafkas, resp, err := factory.KafkaMgmt().GetKafkas(ctx).Execute()
-	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
-		return diag.FromErr(apiErr)
+	filters := map[string]string{}
+	for _, field := range kafkasFilterFields {
+		value, ok := d.Get(field).(string)
+		if !ok {
+			return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field)))
+		}
+
+		filters[field] = value
+	}
+
+	kafkas, err := listKafkas(ctx, factory, buildKafkasSearchQuery(filters))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	if err := d.Set("kafkas", flattenKafkas(kafkas.Items)); err != nil {
+	if err := d.Set(KafkasField, flattenKafkas(kafkas, OAuthTokenEndpointURI(factory))); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -132,27 +127,67 @@ func dataSourceKafkasRead(ctx context.Context, d *schema.ResourceData, m interfa
 	return diags
 }
 
-func flattenKafkas(kafkas []kafkamgmtclient.KafkaRequest) []interface{} {
+// listKafkas returns every kafka instance matching the search query, requesting
+// further pages from the api until all of the matching instances have been read
+func listKafkas(ctx context.Context, factory rhoasAPI.Factory, search string) ([]kafkamgmtclient.KafkaRequest, error) {
+	kafkas := make([]kafkamgmtclient.KafkaRequest, 0)
+
+	for page := 1; ; page++ {
+		request := factory.KafkaMgmt().GetKafkas(ctx).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(kafkasPageSize))
+
+		if search != "" {
+			request = request.Search(search)
+		}
+
+		list, resp, err := request.Execute()
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+
+		kafkas = append(kafkas, list.GetItems()...)
+
+		if len(list.GetItems()) < kafkasPageSize || len(kafkas) >= int(list.GetTotal()) {
+			return kafkas, nil
+		}
+	}
+}
+
+// buildKafkasSearchQuery converts the given filters into a search expression
+// for the kafka list api, e.g. "name = my-kafka and region = us-east-1".
+// Empty filters are ignored and values containing a "%" wildcard are matched
+// using "like" instead of "="
+func buildKafkasSearchQuery(filters map[string]string) string {
+	fields := make([]string, 0, len(filters))
+	for field, value := range filters {
+		if value != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	// sort the fields so the same filters always produce the same query
+	sort.Strings(fields)
+
+	expressions := make([]string, len(fields))
+	for i, field := range fields {
+		operator := "="
+		if strings.Contains(filters[field], "%") {
+			operator = "like"
+		}
+
+		expressions[i] = fmt.Sprintf("%s %s %s", field, operator, filters[field])
+	}
+
+	return strings.Join(expressions, " and ")
+}
+
+func flattenKafkas(kafkas []kafkamgmtclient.KafkaRequest, oauthTokenEndpointURI string) []interface{} {
 	if kafkas != nil {
 		ks := make([]interface{}, len(kafkas), len(kafkas))
 
 		for i := range kafkas {
-			k := make(map[string]interface{})
-
-			k["cloud_provider"] = kafkas[i].GetCloudProvider()
-			k["region"] = kafkas[i].GetRegion()
-			k["name"] = kafkas[i].GetName()
-			k["href"] = kafkas[i].GetHref()
-			k["status"] = kafkas[i].GetStatus()
-			k["owner"] = kafkas[i].GetOwner()
-			k["bootstrap_server_host"] = kafkas[i].GetBootstrapServerHost()
-			k["created_at"] = kafkas[i].GetCreatedAt().Format(time.RFC3339)
-			k["updated_at"] = kafkas[i].GetUpdatedAt().Format(time.RFC3339)
-			k["id"] = kafkas[i].GetId()
-			k["kind"] = kafkas[i].GetKind()
-			k["version"] = kafkas[i].GetVersion()
-
-			ks[i] = k
+			ks[i] = mapKafkaToData(&kafkas[i], oauthTokenEndpointURI)
 		}
 
 		return ks
@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildKafkasSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters map[string]string
+		want    string
+	}{
+		{
+			name:    "no filters",
+			filters: map[string]string{},
+			want:    "",
+		},
+		{
+			name: "empty filters are ignored",
+			filters: map[string]string{
+				NameField:  "",
+				OwnerField: "",
+			},
+			want: "",
+		},
+		{
+			name: "single filter",
+			filters: map[string]string{
+				NameField: "my-kafka",
+			},
+			want: "name = my-kafka",
+		},
+		{
+			name: "multiple filters are sorted and joined",
+			filters: map[string]string{
+				RegionField:        "us-east-1",
+				NameField:          "my-kafka",
+				CloudProviderField: "aws",
+				StatusField:        "",
+			},
+			want: "cloud_provider = aws and name = my-kafka and region = us-east-1",
+		},
+		{
+			name: "wildcards use like",
+			filters: map[string]string{
+				NameField:  "my-%",
+				OwnerField: "someone",
+			},
+			want: "name like my-% and owner = someone",
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildKafkasSearchQuery(tt.filters))
+		})
+	}
+}
+
+func TestListKafkas(t *testing.T) {
+	const total = kafkasPageSize + 20
+
+	var searches []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		searches = append(searches, r.URL.Query().Get("search"))
+
+		items := make([]kafkamgmtclient.KafkaRequest, 0)
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			items = append(items, *kafkamgmtclient.NewKafkaRequest(strconv.Itoa(i), "Kafka", "", false, true))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(kafkamgmtclient.NewKafkaRequestList("KafkaRequestList", int32(page), int32(len(items)), total, items))
+	}))
+	defer server.Close()
+
+	localizer, _ := goi18n.New(nil)
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+	factory := factories.NewDefaultFactory(factories.Options{
+		KafkaClient: kafkaClient,
+		HTTPClient:  server.Client(),
+		Localizer:   localizer,
+	})
+
+	kafkas, err := listKafkas(context.Background(), factory, "name = my-kafka")
+	assert.NoError(t, err, "unexpected error listing kafkas")
+	assert.Len(t, kafkas, total, "expected every page of kafkas to be read")
+	assert.Equal(t, "0", kafkas[0].GetId())
+	assert.Equal(t, strconv.Itoa(total-1), kafkas[total-1].GetId())
+	assert.Equal(t, []string{"name = my-kafka", "name = my-kafka"}, searches, "expected the search to be sent with each page")
+}
@@ -0,0 +1,277 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RegionEnabledField      = "region_enabled"
+	PlanOfferedField        = "plan_offered"
+	MaxCapacityReachedField = "max_capacity_reached"
+	KafkasCreatableField    = "kafkas_creatable"
+	CapacityField           = "capacity"
+	AvailableSizesField     = "available_sizes"
+
+	// the number of regions requested for each page of the list api
+	regionsPageSize = 100
+)
+
+func DataSourceServiceStatus(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_service_status` provides whether new Kafka instances can currently be created in a region of Red Hat OpenShift Streams for Apache Kafka.",
+		ReadContext: dataSourceServiceStatusRead,
+		Schema: map[string]*schema.Schema{
+			CloudProviderField: {
+				Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aws",
+			},
+			RegionField: {
+				Description: localizer.MustLocalize("kafka.resource.field.description.region"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "us-east-1",
+			},
+			PlanField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.plan"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			RegionEnabledField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.regionEnabled"),
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			PlanOfferedField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.planOffered"),
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			MaxCapacityReachedField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.maxCapacityReached"),
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			KafkasCreatableField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.kafkasCreatable"),
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			CapacityField: {
+				Description: localizer.MustLocalize("kafka.status.field.description.capacity"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						InstanceTypeField: {
+							Description: localizer.MustLocalize("kafka.status.field.description.instanceType"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						AvailableSizesField: {
+							Description: localizer.MustLocalize("kafka.status.field.description.availableSizes"),
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServiceStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	cloudProvider, ok := d.Get(CloudProviderField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", CloudProviderField)))
+	}
+
+	region, ok := d.Get(RegionField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RegionField)))
+	}
+
+	plan, ok := d.Get(PlanField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PlanField)))
+	}
+
+	cloudRegion, err := getCloudRegion(ctx, factory, cloudProvider, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if cloudRegion == nil {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("kafka.errors.regionNotFound", localize.NewEntry("Region", region), localize.NewEntry("CloudProvider", cloudProvider)))
+	}
+
+	var instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType
+	if cloudRegion.GetEnabled() {
+		if instanceTypes, err = getSupportedInstanceTypes(ctx, factory, cloudProvider, region); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	capacity := kafkaCapacity(cloudRegion, instanceTypes, plan)
+
+	if err = d.Set(RegionEnabledField, cloudRegion.GetEnabled()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(PlanOfferedField, capacity == capacityAvailable || capacity == maxCapacityReached); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(MaxCapacityReachedField, capacity == maxCapacityReached); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(KafkasCreatableField, capacity == capacityAvailable); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(CapacityField, flattenRegionCapacity(cloudRegion.GetCapacity())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", cloudProvider, region))
+
+	return diags
+}
+
+// getCloudRegion returns the region of the cloud provider with the given id,
+// or nil if the cloud provider does not support the region
+func getCloudRegion(ctx context.Context, factory rhoasAPI.Factory, cloudProvider string, region string) (*kafkamgmtclient.CloudRegion, error) {
+	for page := 1; ; page++ {
+		list, resp, err := factory.KafkaMgmt().GetCloudProviderRegions(ctx, cloudProvider).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(regionsPageSize)).
+			Execute()
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+
+		regions := list.GetItems()
+		for i := range regions {
+			if regions[i].GetId() == region {
+				return &regions[i], nil
+			}
+		}
+
+		if len(regions) < regionsPageSize {
+			return nil, nil
+		}
+	}
+}
+
+// regionCapacity is whether kafka instances of a plan can currently be
+// created in a region, or why they cannot
+type regionCapacity int
+
+const (
+	capacityAvailable regionCapacity = iota
+	regionDisabled
+	planNotOffered
+	maxCapacityReached
+)
+
+// getSupportedInstanceTypes returns the instance types offered in the region of
+// the cloud provider, along with their sizes
+func getSupportedInstanceTypes(ctx context.Context, factory rhoasAPI.Factory, cloudProvider string, region string) ([]kafkamgmtclient.SupportedKafkaInstanceType, error) {
+	list, resp, err := factory.KafkaMgmt().GetInstanceTypesByCloudProviderAndRegion(ctx, cloudProvider, region).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return list.GetInstanceTypes(), nil
+}
+
+// findSupportedSize returns the size of the plan, e.g. "standard.x1", among
+// the instance types, or nil when the plan is not offered
+func findSupportedSize(instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType, plan string) *kafkamgmtclient.SupportedKafkaSize {
+	instanceType, size, _ := ParsePlan(plan)
+
+	for i := range instanceTypes {
+		if instanceTypes[i].GetId() != instanceType {
+			continue
+		}
+
+		sizes := instanceTypes[i].GetSizes()
+		for j := range sizes {
+			if sizes[j].GetId() == size {
+				return &sizes[j]
+			}
+		}
+	}
+
+	return nil
+}
+
+// kafkaCapacity returns whether a kafka instance with the given plan, e.g.
+// "standard.x1", can currently be created in the region given the instance
+// types it offers. Without a plan it returns whether any kafka instance can be
+// created in the region. A plan which is not offered in the region is told
+// apart from a plan which is offered but has no capacity left
+func kafkaCapacity(cloudRegion *kafkamgmtclient.CloudRegion, instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType, plan string) regionCapacity {
+	if !cloudRegion.GetEnabled() {
+		return regionDisabled
+	}
+
+	if (plan == "" && len(instanceTypes) == 0) || (plan != "" && findSupportedSize(instanceTypes, plan) == nil) {
+		return planNotOffered
+	}
+
+	instanceType, size, _ := ParsePlan(plan)
+
+	for _, capacity := range cloudRegion.GetCapacity() {
+		if plan != "" && capacity.GetInstanceType() != instanceType {
+			continue
+		}
+
+		for _, availableSize := range capacity.GetAvailableSizes() {
+			if plan == "" || availableSize == size {
+				return capacityAvailable
+			}
+		}
+	}
+
+	return maxCapacityReached
+}
+
+func flattenRegionCapacity(capacities []kafkamgmtclient.RegionCapacityListItem) []interface{} {
+	cs := make([]interface{}, len(capacities))
+
+	for i, capacity := range capacities {
+		sizes := capacity.GetAvailableSizes()
+		sort.Strings(sizes)
+
+		cs[i] = map[string]interface{}{
+			InstanceTypeField:   capacity.GetInstanceType(),
+			AvailableSizesField: sizes,
+		}
+	}
+
+	return cs
+}
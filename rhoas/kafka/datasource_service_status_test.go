@@ -0,0 +1,172 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCloudRegion(enabled bool, capacity map[string][]string) *kafkamgmtclient.CloudRegion {
+	items := make([]kafkamgmtclient.RegionCapacityListItem, 0, len(capacity))
+	for instanceType, sizes := range capacity {
+		items = append(items, *kafkamgmtclient.NewRegionCapacityListItem(instanceType, sizes))
+	}
+
+	region := kafkamgmtclient.NewCloudRegion(enabled, items)
+	region.SetId("us-east-1")
+
+	return region
+}
+
+// testInstanceTypes returns the instance types offered in a region along with
+// their sizes, each size consuming one streaming unit per x, e.g. 2 for x2
+func testInstanceTypes(offered map[string][]string) []kafkamgmtclient.SupportedKafkaInstanceType {
+	instanceTypes := make([]kafkamgmtclient.SupportedKafkaInstanceType, 0, len(offered))
+	for id, sizeIDs := range offered {
+		sizes := make([]kafkamgmtclient.SupportedKafkaSize, 0, len(sizeIDs))
+		for _, sizeID := range sizeIDs {
+			size := kafkamgmtclient.NewSupportedKafkaSize()
+			size.SetId(sizeID)
+			units, _ := strconv.Atoi(strings.TrimPrefix(sizeID, "x"))
+			size.SetQuotaConsumed(int32(units))
+			sizes = append(sizes, *size)
+		}
+
+		instanceType := kafkamgmtclient.NewSupportedKafkaInstanceType()
+		instanceType.SetId(id)
+		instanceType.SetSizes(sizes)
+		instanceTypes = append(instanceTypes, *instanceType)
+	}
+
+	return instanceTypes
+}
+
+func TestKafkaCapacity(t *testing.T) {
+	region := testCloudRegion(true, map[string][]string{
+		"standard":  {"x1", "x2"},
+		"developer": {},
+	})
+	instanceTypes := testInstanceTypes(map[string][]string{
+		"standard":  {"x1", "x2", "x3"},
+		"developer": {"x1"},
+	})
+
+	tests := []struct {
+		name          string
+		region        *kafkamgmtclient.CloudRegion
+		instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType
+		plan          string
+		want          regionCapacity
+	}{
+		{name: "available size", region: region, instanceTypes: instanceTypes, plan: "standard.x2", want: capacityAvailable},
+		{name: "size at capacity", region: region, instanceTypes: instanceTypes, plan: "standard.x3", want: maxCapacityReached},
+		{name: "instance type at capacity", region: region, instanceTypes: instanceTypes, plan: "developer.x1", want: maxCapacityReached},
+		{name: "size not offered", region: region, instanceTypes: instanceTypes, plan: "standard.x4", want: planNotOffered},
+		{name: "instance type not offered", region: region, instanceTypes: instanceTypes, plan: "enterprise.x1", want: planNotOffered},
+		{name: "any plan", region: region, instanceTypes: instanceTypes, want: capacityAvailable},
+		{name: "any plan at capacity", region: testCloudRegion(true, map[string][]string{"standard": {}}), instanceTypes: instanceTypes, want: maxCapacityReached},
+		{name: "no plan offered", region: region, want: planNotOffered},
+		{name: "disabled region", region: testCloudRegion(false, map[string][]string{"standard": {"x1"}}), instanceTypes: instanceTypes, plan: "standard.x1", want: regionDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, kafkaCapacity(tt.region, tt.instanceTypes, tt.plan)) // nolint:scopelint
+		})
+	}
+}
+
+// testServiceStatusFactory returns a factory whose kafka management api is a
+// local fake serving us-east-1 of aws, where standard x1 to x3 are offered and
+// x1 and x2 have capacity left
+func testServiceStatusFactory(t *testing.T) rhoasAPI.Factory {
+	localizer, _ := goi18n.New(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/kafkas_mgmt/v1/cloud_providers/aws/regions":
+			list := kafkamgmtclient.NewCloudRegionListWithDefaults()
+			list.SetItems([]kafkamgmtclient.CloudRegion{
+				*testCloudRegion(true, map[string][]string{"standard": {"x2", "x1"}}),
+			})
+			body = list
+		case "/api/kafkas_mgmt/v1/instance_types/aws/us-east-1":
+			list := kafkamgmtclient.NewSupportedKafkaInstanceTypesList()
+			list.SetInstanceTypes(testInstanceTypes(map[string][]string{"standard": {"x1", "x2", "x3"}}))
+			body = list
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	return factories.NewDefaultFactory(factories.Options{
+		KafkaClient: kafkaClient,
+		HTTPClient:  server.Client(),
+		Localizer:   localizer,
+	})
+}
+
+func TestDataSourceServiceStatusRead(t *testing.T) {
+	factory := testServiceStatusFactory(t)
+	r := DataSourceServiceStatus(factory.Localizer())
+
+	t.Run("region at capacity for the plan", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{PlanField: "standard.x3"})
+
+		diags := r.ReadContext(context.Background(), d, factory)
+		assert.False(t, diags.HasError(), diags)
+
+		assert.Equal(t, "aws/us-east-1", d.Id())
+		assert.Equal(t, true, d.Get(RegionEnabledField))
+		assert.Equal(t, true, d.Get(PlanOfferedField))
+		assert.Equal(t, true, d.Get(MaxCapacityReachedField))
+		assert.Equal(t, false, d.Get(KafkasCreatableField))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				InstanceTypeField:   "standard",
+				AvailableSizesField: []interface{}{"x1", "x2"},
+			},
+		}, d.Get(CapacityField))
+	})
+
+	t.Run("plan not offered", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{PlanField: "standard.x4"})
+
+		diags := r.ReadContext(context.Background(), d, factory)
+		assert.False(t, diags.HasError(), diags)
+
+		assert.Equal(t, false, d.Get(PlanOfferedField))
+		assert.Equal(t, false, d.Get(MaxCapacityReachedField))
+		assert.Equal(t, false, d.Get(KafkasCreatableField))
+	})
+
+	t.Run("unsupported region", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{RegionField: "eu-west-1"})
+
+		diags := r.ReadContext(context.Background(), d, factory)
+		assert.True(t, diags.HasError())
+	})
+}
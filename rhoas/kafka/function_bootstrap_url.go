@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+var _ function.Function = &bootstrapURLFunction{}
+
+// bootstrapURLFunction is the bootstrap_url provider function, composing the
+// bootstrap url of a kafka instance as the rhoas_kafka_connection data source does
+type bootstrapURLFunction struct {
+	localizer localize.Localizer
+}
+
+func NewBootstrapURLFunction(localizer localize.Localizer) function.Function {
+	return &bootstrapURLFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *bootstrapURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "bootstrap_url"
+}
+
+func (f *bootstrapURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("kafka.function.bootstrapURL.summary"),
+		Description: f.localizer.MustLocalize("kafka.function.bootstrapURL.description"),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "host",
+				Description: f.localizer.MustLocalize("kafka.function.bootstrapURL.parameter.host"),
+			},
+			function.Int64Parameter{
+				Name:           "port",
+				Description:    f.localizer.MustLocalize("kafka.function.bootstrapURL.parameter.port"),
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *bootstrapURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var host string
+	var port types.Int64
+
+	resp.Error = req.Arguments.Get(ctx, &host, &port)
+	if resp.Error != nil {
+		return
+	}
+
+	// a null port keeps the port of the host, which BootstrapURL does for 0
+	if !port.IsNull() && port.ValueInt64() == 0 {
+		resp.Error = function.NewArgumentFuncError(1, "the port 0 must be between 1 and 65535")
+		return
+	}
+
+	url, err := BootstrapURL(host, port.ValueInt64())
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, url)
+}
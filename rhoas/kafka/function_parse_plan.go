@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+const SizeField = "size"
+
+var _ function.Function = &parsePlanFunction{}
+
+// parsePlanFunction is the parse_plan provider function, splitting the plan of
+// a kafka instance as the rhoas_kafka resource does
+type parsePlanFunction struct {
+	localizer localize.Localizer
+}
+
+// planModel maps the object returned by the parse_plan function
+type planModel struct {
+	InstanceType types.String `tfsdk:"instance_type"`
+	Size         types.String `tfsdk:"size"`
+}
+
+func NewParsePlanFunction(localizer localize.Localizer) function.Function {
+	return &parsePlanFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *parsePlanFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_plan"
+}
+
+func (f *parsePlanFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("kafka.function.parsePlan.summary"),
+		Description: f.localizer.MustLocalize("kafka.function.parsePlan.description"),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "plan",
+				Description: f.localizer.MustLocalize("kafka.resource.field.description.plan"),
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				InstanceTypeField: types.StringType,
+				SizeField:         types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parsePlanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var plan string
+
+	resp.Error = req.Arguments.Get(ctx, &plan)
+	if resp.Error != nil {
+		return
+	}
+
+	instanceType, size, err := ParsePlan(plan)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, planModel{
+		InstanceType: types.StringValue(instanceType),
+		Size:         types.StringValue(size),
+	})
+}
@@ -0,0 +1,51 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+var _ function.Function = &validKafkaNameFunction{}
+
+// validKafkaNameFunction is the valid_kafka_name provider function, checking
+// a name with the rules of the rhoas_kafka resource
+type validKafkaNameFunction struct {
+	localizer localize.Localizer
+}
+
+func NewValidKafkaNameFunction(localizer localize.Localizer) function.Function {
+	return &validKafkaNameFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *validKafkaNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "valid_kafka_name"
+}
+
+func (f *validKafkaNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("kafka.function.validKafkaName.summary"),
+		Description: f.localizer.MustLocalize("kafka.function.validKafkaName.description"),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: f.localizer.MustLocalize("kafka.function.validKafkaName.parameter.name"),
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validKafkaNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, ValidateKafkaName(name) == nil)
+}
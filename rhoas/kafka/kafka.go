@@ -0,0 +1,264 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+// OAuthTokenEndpointURI returns the endpoint used by kafka clients to exchange
+// the service account credentials for an access token
+func OAuthTokenEndpointURI(factory rhoasAPI.Factory) string {
+	return fmt.Sprintf("%s/%s", factory.AuthURL(), "protocol/openid-connect/token")
+}
+
+// withComputedKafkaSchema adds the attributes which are only ever read from the
+// api to the given schema. These are shared by the rhoas_kafka resource and
+// the rhoas_kafka and rhoas_kafkas data sources
+// nolint:funlen
+func withComputedKafkaSchema(localizer localize.Localizer, s map[string]*schema.Schema) map[string]*schema.Schema {
+	computed := map[string]*schema.Schema{
+		HrefField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.href"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		StatusField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.status"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		OwnerField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.owner"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		BootstrapServerHostField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.bootstrapServerHost"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		AdminAPIServerURLField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.adminApiServerUrl"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		BrowserURLField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.browserUrl"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		OAuthTokenEndpointURIField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.oauthTokenEndpointUri"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		FailedReasonField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.failedReason"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		InstanceTypeField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.instanceType"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		SizeIDField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.sizeId"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		MultiAZField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.multiAz"),
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		IngressThroughputPerSecField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.ingressThroughputPerSec"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		EgressThroughputPerSecField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.egressThroughputPerSec"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		TotalMaxConnectionsField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.totalMaxConnections"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		MaxConnectionAttemptsPerSecField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.maxConnectionAttemptsPerSec"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		MaxPartitionsField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.maxPartitions"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		MaxDataRetentionPeriodField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.maxDataRetentionPeriod"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		MaxDataRetentionSizeField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.maxDataRetentionSize"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		CreatedAtField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.createdAt"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		UpdatedAtField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.updatedAt"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		ExpiresAtField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.expiresAt"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		KindField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.kind"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		VersionField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.version"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	for field, fieldSchema := range computed {
+		s[field] = fieldSchema
+	}
+
+	return s
+}
+
+// dataSourceKafkaSchema returns the schema of a kafka instance where every
+// attribute, including the ones given as arguments to the resource, is read
+// from the api
+func dataSourceKafkaSchema(localizer localize.Localizer) map[string]*schema.Schema {
+	return withComputedKafkaSchema(localizer, map[string]*schema.Schema{
+		IDField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.id"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		NameField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.name"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		CloudProviderField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		RegionField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.region"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		ReauthenticationEnabledField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.reauthenticationEnabled"),
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		PlanField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.plan"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		BillingCloudAccountIDField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.billingCloudAccountId"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		MarketPlaceField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.marketplace"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		BillingModelField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.billingModel"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	})
+}
+
+// mapKafkaToData maps a kafka instance returned by the api to the attributes
+// of the rhoas_kafka resource and data sources, given the endpoint of the sso
+// server issuing the access tokens of its clients
+func mapKafkaToData(kafka *kafkamgmtclient.KafkaRequest, oauthTokenEndpointURI string) map[string]interface{} {
+	data := map[string]interface{}{
+		IDField:                          kafka.GetId(),
+		KindField:                        kafka.GetKind(),
+		HrefField:                        kafka.GetHref(),
+		StatusField:                      kafka.GetStatus(),
+		OwnerField:                       kafka.GetOwner(),
+		ReauthenticationEnabledField:     kafka.GetReauthenticationEnabled(),
+		BillingCloudAccountIDField:       kafka.GetBillingCloudAccountId(),
+		MarketPlaceField:                 kafka.GetMarketplace(),
+		BootstrapServerHostField:         kafka.GetBootstrapServerHost(),
+		AdminAPIServerURLField:           kafka.GetAdminApiServerUrl(),
+		BrowserURLField:                  kafka.GetBrowserUrl(),
+		OAuthTokenEndpointURIField:       oauthTokenEndpointURI,
+		FailedReasonField:                kafka.GetFailedReason(),
+		InstanceTypeField:                kafka.GetInstanceType(),
+		SizeIDField:                      kafka.GetSizeId(),
+		MultiAZField:                     kafka.GetMultiAz(),
+		IngressThroughputPerSecField:     kafka.GetIngressThroughputPerSec(),
+		EgressThroughputPerSecField:      kafka.GetEgressThroughputPerSec(),
+		TotalMaxConnectionsField:         int(kafka.GetTotalMaxConnections()),
+		MaxConnectionAttemptsPerSecField: int(kafka.GetMaxConnectionAttemptsPerSec()),
+		MaxPartitionsField:               int(kafka.GetMaxPartitions()),
+		MaxDataRetentionPeriodField:      kafka.GetMaxDataRetentionPeriod(),
+		MaxDataRetentionSizeField:        0,
+		CreatedAtField:                   kafka.GetCreatedAt().Format(time.RFC3339),
+		UpdatedAtField:                   kafka.GetUpdatedAt().Format(time.RFC3339),
+		ExpiresAtField:                   DefaultEmptyField,
+		VersionField:                     kafka.GetVersion(),
+	}
+
+	if maxDataRetentionSize, ok := kafka.GetMaxDataRetentionSizeOk(); ok {
+		data[MaxDataRetentionSizeField] = int(maxDataRetentionSize.GetBytes())
+	}
+
+	if expiresAt, ok := kafka.GetExpiresAtOk(); ok && expiresAt != nil {
+		data[ExpiresAtField] = expiresAt.Format(time.RFC3339)
+	}
+
+	// the fields given as arguments to the resource are only set when the api
+	// returns them, so that an instance missing them is not planned for replacement
+	arguments := map[string]string{
+		NameField:          kafka.GetName(),
+		CloudProviderField: kafka.GetCloudProvider(),
+		RegionField:        kafka.GetRegion(),
+		BillingModelField:  kafka.GetBillingModel(),
+	}
+
+	if kafka.GetInstanceType() != "" && kafka.GetSizeId() != "" {
+		arguments[PlanField] = fmt.Sprintf("%s.%s", kafka.GetInstanceType(), kafka.GetSizeId())
+	}
+
+	for field, value := range arguments {
+		if value != DefaultEmptyField {
+			data[field] = value
+		}
+	}
+
+	return data
+}
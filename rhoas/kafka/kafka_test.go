@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKafkaRequest() *kafkamgmtclient.KafkaRequest {
+	kafka := kafkamgmtclient.NewKafkaRequest("test-id", "Kafka", "/api/kafkas_mgmt/v1/kafkas/test-id", true, true)
+	kafka.SetName("test-kafka")
+	kafka.SetStatus("ready")
+	kafka.SetCloudProvider("aws")
+	kafka.SetRegion("us-east-1")
+	kafka.SetBillingModel("standard")
+	kafka.SetInstanceType("standard")
+	kafka.SetSizeId("x2")
+	kafka.SetAdminApiServerUrl("https://admin-server-test.kafka.example.com")
+	kafka.SetMaxPartitions(1000)
+	kafka.SetMaxDataRetentionSize(kafkamgmtclient.SupportedKafkaSizeBytesValueItem{Bytes: int64Ptr(1000)})
+	kafka.SetCreatedAt(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	return kafka
+}
+
+const testOAuthTokenEndpointURI = "https://sso.example.com/auth/realms/test/protocol/openid-connect/token"
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestMapKafkaToData(t *testing.T) {
+	t.Run("maps the kafka request fields", func(t *testing.T) {
+		data := mapKafkaToData(testKafkaRequest(), testOAuthTokenEndpointURI)
+
+		assert.Equal(t, "test-id", data[IDField])
+		assert.Equal(t, "test-kafka", data[NameField])
+		assert.Equal(t, "standard.x2", data[PlanField], "expected the plan to be built from the instance type and size id")
+		assert.Equal(t, "https://admin-server-test.kafka.example.com", data[AdminAPIServerURLField])
+		assert.Equal(t, 1000, data[MaxPartitionsField])
+		assert.Equal(t, 1000, data[MaxDataRetentionSizeField])
+		assert.Equal(t, true, data[MultiAZField])
+		assert.Equal(t, "2022-01-02T03:04:05Z", data[CreatedAtField])
+		assert.Equal(t, "", data[ExpiresAtField], "expected no expiry for an instance without expires_at")
+		assert.Equal(t, testOAuthTokenEndpointURI, data[OAuthTokenEndpointURIField])
+	})
+
+	t.Run("formats the expiry", func(t *testing.T) {
+		kafka := testKafkaRequest()
+		kafka.SetExpiresAt(time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC))
+
+		assert.Equal(t, "2022-03-04T05:06:07Z", mapKafkaToData(kafka, testOAuthTokenEndpointURI)[ExpiresAtField])
+	})
+
+	t.Run("omits arguments the api did not return", func(t *testing.T) {
+		kafka := kafkamgmtclient.NewKafkaRequest("test-id", "Kafka", "", false, true)
+		kafka.SetInstanceType("developer")
+
+		data := mapKafkaToData(kafka, testOAuthTokenEndpointURI)
+		for _, field := range []string{NameField, CloudProviderField, RegionField, BillingModelField, PlanField} {
+			assert.NotContains(t, data, field)
+		}
+	})
+}
+
+func TestMapKafkaToDataMatchesSchemas(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+
+	kafkasElem, ok := DataSourceKafkas(localizer).Schema[KafkasField].Elem.(*schema.Resource)
+	if !ok {
+		t.Fatalf("expected the %s field to be a list of resources", KafkasField)
+	}
+
+	resources := map[string]*schema.Resource{
+		"resource":            ResourceKafka(localizer),
+		"data source":         DataSourceKafka(localizer),
+		"list of data source": kafkasElem,
+	}
+
+	for name, r := range resources {
+		// nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			for field := range mapKafkaToData(testKafkaRequest(), testOAuthTokenEndpointURI) {
+				assert.Contains(t, r.Schema, field)
+			}
+		})
+	}
+
+	t.Run("resource data can be set", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, ResourceKafka(localizer).Schema, map[string]interface{}{})
+
+		assert.NoError(t, setResourceDataFromKafkaData(d, mapKafkaToData(testKafkaRequest(), testOAuthTokenEndpointURI)))
+		assert.Equal(t, "standard.x2", d.Get(PlanField))
+		assert.Equal(t, 1000, d.Get(MaxPartitionsField))
+	})
+}
+
+func TestSetKafkaResourceData(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		AuthURL:   "https://sso.example.com/auth/realms/test",
+		Localizer: localizer,
+	})
+
+	d := schema.TestResourceDataRaw(t, ResourceKafka(localizer).Schema, map[string]interface{}{
+		NameField:         "test-kafka",
+		RegionField:       "us-east-1",
+		PlanField:         "standard.x1",
+		BillingModelField: "marketplace",
+	})
+
+	// the api returns the instance with another region, plan and billing model than configured
+	kafka := testKafkaRequest()
+	kafka.SetRegion("eu-west-1")
+
+	assert.NoError(t, setKafkaResourceData(factory, d, kafka))
+
+	for field, want := range map[string]string{
+		RegionField:       "us-east-1",
+		PlanField:         "standard.x1",
+		BillingModelField: "marketplace",
+	} {
+		assert.Equal(t, want, d.Get(field), "expected the %s argument to be kept as configured", field)
+	}
+
+	assert.Equal(t, "x2", d.Get(SizeIDField))
+	assert.Equal(t, testOAuthTokenEndpointURI, d.Get(OAuthTokenEndpointURIField))
+
+	t.Run("arguments force a new instance", func(t *testing.T) {
+		resourceSchema := ResourceKafka(localizer).Schema
+		for _, field := range kafkaResourceArguments {
+			assert.True(t, resourceSchema[field].ForceNew, "expected %s to force a new instance", field)
+		}
+
+		for field := range mapKafkaToData(testKafkaRequest(), testOAuthTokenEndpointURI) {
+			if resourceSchema[field].ForceNew {
+				assert.Contains(t, kafkaResourceArguments, field)
+			}
+		}
+	})
+}
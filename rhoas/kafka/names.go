@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxKafkaNameLength = 32
+
+	// the port of the bootstrap servers of kafka instances
+	defaultBootstrapPort = 443
+)
+
+// kafkaNamePattern matches the names accepted by the api for kafka instances
+var kafkaNamePattern = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// ValidateKafkaName returns an error when the name is not a valid name for a
+// kafka instance: up to 32 lowercase letters, digits and hyphens, starting
+// with a letter and ending with a letter or a digit
+func ValidateKafkaName(name string) error {
+	if len(name) > maxKafkaNameLength {
+		return fmt.Errorf("the name %q must be at most %d characters long", name, maxKafkaNameLength)
+	}
+
+	if !kafkaNamePattern.MatchString(name) {
+		return fmt.Errorf("the name %q must consist of lowercase letters, digits and hyphens, start with a letter and end with a letter or a digit", name)
+	}
+
+	return nil
+}
+
+// ParsePlan returns the instance type and the size of a plan, e.g. "standard"
+// and "x2" for "standard.x2"
+func ParsePlan(plan string) (string, string, error) {
+	instanceType, size, found := strings.Cut(plan, ".")
+	if !found || instanceType == "" || size == "" || strings.Contains(size, ".") {
+		return instanceType, size, fmt.Errorf("the plan %q must be of the form <instance type>.<size>, e.g. %q", plan, "standard.x1")
+	}
+
+	return instanceType, size, nil
+}
+
+// validatePlan returns an error when the plan is not of the form <instance type>.<size>
+func validatePlan(plan string) error {
+	_, _, err := ParsePlan(plan)
+
+	return err
+}
+
+// BootstrapURL returns the bootstrap server host of a kafka instance with the
+// given port, replacing the port of the host if any. Without a port, i.e. 0, the
+// port of the host is kept, or the default port 443 is used when it has none
+func BootstrapURL(host string, port int64) (string, error) {
+	if port < 0 || port > 65535 {
+		return "", fmt.Errorf("the port %d must be between 1 and 65535", port)
+	}
+
+	hostname, hostPort, err := net.SplitHostPort(host)
+	if err != nil {
+		// the host has no port
+		hostname, hostPort = strings.Trim(host, "[]"), strconv.Itoa(defaultBootstrapPort)
+	}
+
+	if hostname == "" {
+		return "", fmt.Errorf("the bootstrap server host %q must not be empty", host)
+	}
+
+	if port != 0 {
+		hostPort = strconv.FormatInt(port, 10)
+	}
+
+	return net.JoinHostPort(hostname, hostPort), nil
+}
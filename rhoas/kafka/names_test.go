@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKafkaName(t *testing.T) {
+	assert.NoError(t, ValidateKafkaName("orders"))
+	assert.NoError(t, ValidateKafkaName("orders-eu-1"))
+	assert.NoError(t, ValidateKafkaName("a2345678901234567890123456789012"))
+
+	assert.Error(t, ValidateKafkaName(""))
+	assert.Error(t, ValidateKafkaName("Orders"))
+	assert.Error(t, ValidateKafkaName("1-orders"))
+	assert.Error(t, ValidateKafkaName("orders-"))
+	assert.Error(t, ValidateKafkaName("orders_eu"))
+	assert.Error(t, ValidateKafkaName("a23456789012345678901234567890123"))
+}
+
+func TestParsePlan(t *testing.T) {
+	instanceType, size, err := ParsePlan("standard.x2")
+	assert.NoError(t, err)
+	assert.Equal(t, "standard", instanceType)
+	assert.Equal(t, "x2", size)
+
+	for _, plan := range []string{"", "standard", "standard.", ".x2", "standard.x2.x3"} {
+		_, _, err := ParsePlan(plan)
+		assert.Error(t, err, "expected an error for the plan %q", plan)
+	}
+}
+
+func TestBootstrapURL(t *testing.T) {
+	tests := []struct {
+		host    string
+		port    int64
+		want    string
+		wantErr bool
+	}{
+		{host: "test-kafka.example.com:443", want: "test-kafka.example.com:443"},
+		{host: "test-kafka.example.com", want: "test-kafka.example.com:443"},
+		{host: "test-kafka.example.com:443", port: 9096, want: "test-kafka.example.com:9096"},
+		{host: "test-kafka.example.com", port: 9096, want: "test-kafka.example.com:9096"},
+		{host: "[::1]", want: "[::1]:443"},
+		{host: "", wantErr: true},
+		{host: ":443", wantErr: true},
+		{host: "test-kafka.example.com", port: 65536, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		url, err := BootstrapURL(tt.host, tt.port)
+		if tt.wantErr {
+			assert.Error(t, err, "expected an error for the host %q and the port %d", tt.host, tt.port)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, url)
+	}
+}
@@ -2,6 +2,8 @@ package kafka
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -9,9 +11,11 @@ import (
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/acl"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
@@ -38,130 +42,278 @@ const (
 	VersionField                 = "version"
 	ACLField                     = "acl"
 
+	AdminAPIServerURLField           = "admin_api_server_url"
+	BrowserURLField                  = "browser_url"
+	OAuthTokenEndpointURIField       = "oauth_token_endpoint_uri"
+	FailedReasonField                = "failed_reason"
+	InstanceTypeField                = "instance_type"
+	SizeIDField                      = "size_id"
+	MultiAZField                     = "multi_az"
+	IngressThroughputPerSecField     = "ingress_throughput_per_sec"
+	EgressThroughputPerSecField      = "egress_throughput_per_sec"
+	TotalMaxConnectionsField         = "total_max_connections"
+	MaxConnectionAttemptsPerSecField = "max_connection_attempts_per_sec"
+	MaxPartitionsField               = "max_partitions"
+	MaxDataRetentionPeriodField      = "max_data_retention_period"
+	MaxDataRetentionSizeField        = "max_data_retention_size"
+	ExpiresAtField                   = "expires_at"
+	OnFailureField                   = "on_failure"
+	WaitForReadyField                = "wait_for_ready"
+	CheckCapacityField               = "check_capacity"
+
+	// OnFailureTaint keeps a kafka instance which failed to be created so that
+	// it is tainted and replaced on the next apply
+	OnFailureTaint = "taint"
+	// OnFailureDelete deletes a kafka instance which failed to be created
+	OnFailureDelete = "delete"
+
 	DefaultEmptyField = ""
+
+	developerInstanceType = "developer"
 )
 
+// kafkaResourceArguments are the arguments of the rhoas_kafka resource which
+// are sent to the api when creating the instance, and so force a new instance
+var kafkaResourceArguments = []string{
+	NameField,
+	CloudProviderField,
+	RegionField,
+	ReauthenticationEnabledField,
+	PlanField,
+	BillingCloudAccountIDField,
+	MarketPlaceField,
+	BillingModelField,
+}
+
 // nolint:funlen
 func ResourceKafka(localizer localize.Localizer) *schema.Resource {
+	kafkaSchema := withComputedKafkaSchema(localizer, map[string]*schema.Schema{
+		NameField: {
+			Description:  localizer.MustLocalize("kafka.resource.field.description.name"),
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: utils.StringValidateFunc(ValidateKafkaName),
+		},
+		CloudProviderField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "aws",
+			ForceNew:    true,
+		},
+		RegionField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.region"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "us-east-1",
+			ForceNew:    true,
+		},
+		ReauthenticationEnabledField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.reauthenticationEnabled"),
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			ForceNew:    true,
+		},
+		PlanField: {
+			Description:  localizer.MustLocalize("kafka.resource.field.description.plan"),
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: utils.StringValidateFunc(validatePlan),
+		},
+		BillingCloudAccountIDField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.billingCloudAccountId"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     DefaultEmptyField,
+			ForceNew:    true,
+		},
+		MarketPlaceField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.marketplace"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     DefaultEmptyField,
+			ForceNew:    true,
+		},
+		BillingModelField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.billingModel"),
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		IDField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.id"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		OnFailureField: {
+			Description:  localizer.MustLocalize("kafka.resource.field.description.onFailure"),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      OnFailureTaint,
+			ValidateFunc: validation.StringInSlice([]string{OnFailureTaint, OnFailureDelete}, false),
+		},
+		WaitForReadyField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.waitForReady"),
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		CheckCapacityField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.checkCapacity"),
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		utils.DeletionProtectionField: utils.DeletionProtectionSchema(localizer),
+		ACLField: {
+			Description: localizer.MustLocalize("kafka.resource.field.description.acl"),
+			Type:        schema.TypeList,
+			ForceNew:    true,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: schema.TypeString,
+			},
+		},
+	})
+
 	return &schema.Resource{
 		Description:   "`rhoas_kafka` manages a Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
 		CreateContext: kafkaCreate,
 		ReadContext:   kafkaRead,
+		UpdateContext: kafkaUpdate,
 		DeleteContext: kafkaDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
-		Schema: map[string]*schema.Schema{
-			NameField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.name"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			CloudProviderField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.cloudProvider"),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "aws",
-				ForceNew:    true,
-			},
-			RegionField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.region"),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "us-east-1",
-				ForceNew:    true,
-			},
-			ReauthenticationEnabledField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.reauthenticationEnabled"),
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				ForceNew:    true,
-			},
-			PlanField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.plan"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			BillingCloudAccountIDField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.billingCloudAccountId"),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     DefaultEmptyField,
-				ForceNew:    true,
-			},
-			MarketPlaceField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.marketplace"),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     DefaultEmptyField,
-				ForceNew:    true,
-			},
-			BillingModelField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.billingModel"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			HrefField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.href"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			StatusField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.status"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			OwnerField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.owner"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			BootstrapServerHostField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.bootstrapServerHost"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			CreatedAtField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.createdAt"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			UpdatedAtField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.updatedAt"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			IDField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.id"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			KindField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.kind"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			VersionField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.version"),
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			ACLField: {
-				Description: localizer.MustLocalize("kafka.resource.field.description.acl"),
-				Type:        schema.TypeList,
-				ForceNew:    true,
-				Optional:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeMap,
-					Elem: schema.TypeString,
-				},
-			},
-		},
+		CustomizeDiff: customdiff.All(
+			utils.DeletionProtectionCustomizeDiff(kafkaSchema),
+			kafkaACLCustomizeDiff,
+			kafkaCapacityCustomizeDiff,
+			utils.ReferencesCustomizeDiff("", ACLField+".#."+acl.PrincipalField),
+		),
+		Schema: kafkaSchema,
+	}
+}
+
+// checkKafkaQuota fails early when the quotas of the organization for the
+// billing of the kafka instance are known to be exhausted for the streaming
+// units of its size. The quota is advisory, so when it or the size cannot be
+// looked up the instance is created and the api reports any quota error
+func checkKafkaQuota(ctx context.Context, factory rhoasAPI.Factory, payload *kafkamgmtclient.KafkaRequestPayload) error {
+	// developer instances can be created without any quota
+	if instanceType, _, _ := ParsePlan(payload.GetPlan()); instanceType == developerInstanceType || factory.AccountMgmt() == nil {
+		return nil
+	}
+
+	instanceTypes, err := getSupportedInstanceTypes(ctx, factory, payload.GetCloudProvider(), payload.GetRegion())
+	if err != nil {
+		tflog.Warn(ctx, factory.Localizer().MustLocalize("kafka.errors.quotaLookupFailed", localize.NewEntry("Error", err)))
+		return nil
+	}
+
+	size := findSupportedSize(instanceTypes, payload.GetPlan())
+	if size == nil {
+		return nil
+	}
+
+	_, quotas, err := getKafkaQuotas(ctx, factory)
+	if err != nil {
+		tflog.Warn(ctx, factory.Localizer().MustLocalize("kafka.errors.quotaLookupFailed", localize.NewEntry("Error", err)))
+		return nil
+	}
+
+	return validateKafkaBilling(factory, quotas, int(size.GetQuotaConsumed()), payload.GetBillingModel(), payload.GetBillingCloudAccountId(), payload.GetMarketplace())
+}
+
+// kafkaACLCustomizeDiff refuses to plan acls for a kafka instance which is not
+// waited for, as the acls can only be created once the instance is ready
+func kafkaACLCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(WaitForReadyField) || !d.NewValueKnown(ACLField) {
+		return nil
+	}
+
+	waitForReady, _ := d.Get(WaitForReadyField).(bool)
+	aclInput, _ := d.Get(ACLField).([]interface{})
+	if waitForReady || len(aclInput) == 0 {
+		return nil
+	}
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return fmt.Errorf("unable to cast %v to rhoasAPI.Factory", m)
 	}
+
+	return factory.Localizer().MustLocalizeError("kafka.errors.aclRequiresWaitForReady", localize.NewEntry("ACLField", ACLField), localize.NewEntry("WaitForReadyField", WaitForReadyField))
+}
+
+// kafkaCapacityCustomizeDiff warns when a kafka instance is planned to be
+// created in a region which does not accept new instances, does not offer its
+// plan or is at capacity for it, as creating the instance would only fail once
+// it is provisioned. The sdk does not support warnings in a plan, so the warning
+// is logged, unless check_capacity is true which fails the plan instead. The
+// capacity is advisory, so when it cannot be looked up the plan succeeds and
+// creating the instance reports any error
+func kafkaCapacityCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// an existing instance is not checked, a replaced instance, whatever field
+	// forces its replacement, is diffed again without its state by the sdk
+	if d.Id() != "" {
+		return nil
+	}
+
+	for _, field := range []string{CloudProviderField, RegionField, PlanField, CheckCapacityField} {
+		if !d.NewValueKnown(field) {
+			return nil
+		}
+	}
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return nil
+	}
+
+	cloudProvider, _ := d.Get(CloudProviderField).(string)
+	region, _ := d.Get(RegionField).(string)
+	plan, _ := d.Get(PlanField).(string)
+	checkCapacity, _ := d.Get(CheckCapacityField).(bool)
+
+	cloudRegion, err := getCloudRegion(ctx, factory, cloudProvider, region)
+	if err != nil || cloudRegion == nil {
+		return nil
+	}
+
+	var instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType
+	if cloudRegion.GetEnabled() {
+		if instanceTypes, err = getSupportedInstanceTypes(ctx, factory, cloudProvider, region); err != nil {
+			return nil
+		}
+	}
+
+	var messageID string
+	switch kafkaCapacity(cloudRegion, instanceTypes, plan) {
+	case regionDisabled:
+		messageID = "kafka.errors.regionDisabled"
+	case planNotOffered:
+		messageID = "kafka.errors.planNotOffered"
+	case maxCapacityReached:
+		messageID = "kafka.errors.atCapacity"
+	default:
+		return nil
+	}
+
+	err = factory.Localizer().MustLocalizeError(messageID, localize.NewEntry("Plan", plan), localize.NewEntry("Region", region), localize.NewEntry("CloudProvider", cloudProvider))
+	if checkCapacity {
+		return err
+	}
+
+	log.Printf("[WARN] %s, set %s to true to fail the plan instead", err, CheckCapacityField)
+
+	return nil
 }
 
 func kafkaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -173,19 +325,37 @@ func kafkaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
 	}
 
-	_, resp, err := factory.KafkaMgmt().DeleteKafkaById(ctx, d.Id()).Async(true).Execute()
+	err := utils.CheckDeletionProtection(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteKafka(ctx, factory, d.Id(), d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// deleteKafka deletes the kafka instance with the given id and waits until it is gone
+func deleteKafka(ctx context.Context, factory rhoasAPI.Factory, id string, timeout time.Duration) error {
+	_, resp, err := factory.KafkaMgmt().DeleteKafkaById(ctx, id).Async(true).Execute()
 	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
-		return diag.FromErr(apiErr)
+		return apiErr
 	}
 
-	deleteStateConf := &resource.StateChangeConf{
-		Delay: 5 * time.Second,
-		Pending: []string{
+	deleteStateConf := utils.NewStateChangeConf(
+		[]string{
 			"deprovision", "deleting",
 		},
-		Refresh: func() (interface{}, string, error) {
-			data, resp, err1 := factory.KafkaMgmt().GetKafkaById(ctx, d.Id()).Execute()
-			if utils.CheckNotFound(resp) {
+		[]string{
+			"deleted", "",
+		},
+		func() (interface{}, string, error) {
+			data, resp, err1 := factory.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+			if resp != nil && utils.CheckNotFound(resp) {
 				return data, "deleted", nil
 			}
 			if apiErr := utils.GetAPIError(factory, resp, err1); apiErr != nil {
@@ -194,24 +364,25 @@ func kafkaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 
 			return data, *data.Status, nil
 		},
-		Target: []string{
-			"deleted", "",
-		},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		MinTimeout:                5 * time.Second,
-		NotFoundChecks:            0,
-		ContinuousTargetOccurence: 0,
-	}
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if !strings.Contains(err.Error(), "not found") {
-			return diag.FromErr(errors.Wrapf(err, "Error waiting for example instance (%s) to be deleted", d.Id()))
+			return errors.Wrapf(err, "Error waiting for example instance (%s) to be deleted", id)
 		}
 	}
 
-	d.SetId("")
-	return diags
+	return nil
+}
+
+func kafkaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// every argument sent to the api forces a new instance, the remaining
+	// arguments only change the behaviour of the provider
+	return kafkaRead(ctx, d, m)
 }
 
 func kafkaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -225,12 +396,17 @@ func kafkaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 
 	kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, d.Id()).Execute()
 	if err != nil {
+		if resp != nil && utils.CheckNotFound(resp) {
+			// the instance is gone so remove it from the state
+			d.SetId("")
+			return diags
+		}
 		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
 			return diag.FromErr(apiErr)
 		}
 	}
 
-	err = setResourceDataFromKafkaData(d, &kafka)
+	err = setKafkaResourceData(factory, d, &kafka)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -247,11 +423,22 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
 	}
 
+	// acls are only planned along with wait_for_ready, see kafkaACLCustomizeDiff
+	waitForReady, ok := d.Get(WaitForReadyField).(bool)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", WaitForReadyField)))
+	}
+
 	requestPayload, err := mapResourceDataToKafkaPayload(factory, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = checkKafkaQuota(ctx, factory, requestPayload)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	kr, resp, err := factory.KafkaMgmt().CreateKafka(ctx).Async(true).KafkaRequestPayload(*requestPayload).Execute()
 	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
 		return diag.FromErr(apiErr)
@@ -259,52 +446,107 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 
 	d.SetId(kr.Id)
 
-	createStateConf := &resource.StateChangeConf{
-		Delay: 5 * time.Second,
-		Pending: []string{
+	if !waitForReady {
+		// the instance has only been accepted, it is read again once it is ready
+		err = setKafkaResourceData(factory, d, &kr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+
+	kafka, err := waitForKafkaReady(ctx, factory, kr.Id, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		if kafka != nil {
+			return handleKafkaCreationFailure(ctx, factory, d, kafka, err)
+		}
+		return diag.FromErr(err)
+	}
+
+	err = setKafkaResourceData(factory, d, kafka)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// now that kafka is created define the acl
+	err = createACLForKafka(ctx, factory, d, kafka)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// waitForKafkaReady waits until the kafka instance with the given id is ready.
+// When the instance fails instead, the failed instance is returned along with
+// an error giving the reason of the failure
+func waitForKafkaReady(ctx context.Context, factory rhoasAPI.Factory, id string, timeout time.Duration) (*kafkamgmtclient.KafkaRequest, error) {
+	// set when the instance reaches the failed status so the reason can be reported
+	var failedKafka *kafkamgmtclient.KafkaRequest
+
+	readyStateConf := utils.NewStateChangeConf(
+		[]string{
 			"accepted",
 			"preparing",
 			"provisioning",
 		},
-		Refresh: func() (interface{}, string, error) {
-			kafka, resp, err1 := factory.KafkaMgmt().GetKafkaById(ctx, kr.Id).Execute()
-			if apiErr := utils.GetAPIError(factory, resp, err1); apiErr != nil {
+		[]string{
+			"ready",
+		},
+		func() (interface{}, string, error) {
+			kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
 				return nil, "", apiErr
 			}
 
+			if kafka.GetStatus() == "failed" {
+				failedKafka = &kafka
+				return kafka, kafka.GetStatus(), factory.Localizer().MustLocalizeError("kafka.errors.creationFailed", localize.NewEntry("Name", kafka.GetName()), localize.NewEntry("Reason", kafka.GetFailedReason()))
+			}
+
 			return kafka, kafka.GetStatus(), nil
 		},
-		Target: []string{
-			"ready",
-		},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		MinTimeout:                5 * time.Second,
-		NotFoundChecks:            0,
-		ContinuousTargetOccurence: 0,
-	}
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
 
-	data, err := createStateConf.WaitForStateContext(ctx)
+	data, err := readyStateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return failedKafka, err
 	}
 
-	kafka, castOk := data.(kafkamgmtclient.KafkaRequest)
-	if !castOk {
-		return diag.Errorf("Cannot cast data from kafka creation to to map[string]interface{}")
+	kafka, ok := data.(kafkamgmtclient.KafkaRequest)
+	if !ok {
+		return nil, errors.Errorf("Cannot cast data from kafka creation to to map[string]interface{}")
 	}
 
-	err = setResourceDataFromKafkaData(d, &kafka)
-	if err != nil {
-		return diag.FromErr(err)
+	return &kafka, nil
+}
+
+// handleKafkaCreationFailure either deletes the failed kafka instance or keeps it
+// in the state, in which case terraform marks it as tainted as the creation failed
+func handleKafkaCreationFailure(ctx context.Context, factory rhoasAPI.Factory, d *schema.ResourceData, kafka *kafkamgmtclient.KafkaRequest, failure error) diag.Diagnostics {
+	onFailure, ok := d.Get(OnFailureField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", OnFailureField)))
 	}
 
-	// now that kafka is created define the acl
-	err = createACLForKafka(ctx, factory, d, &kafka)
-	if err != nil {
+	if onFailure == OnFailureDelete {
+		if err := deleteKafka(ctx, factory, kafka.GetId(), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(errors.Wrapf(failure, "unable to delete the failed kafka instance: %v", err))
+		}
+
+		d.SetId("")
+		return diag.FromErr(factory.Localizer().MustLocalizeError("kafka.errors.creationFailedDeleted", localize.NewEntry("Name", kafka.GetName()), localize.NewEntry("Reason", kafka.GetFailedReason())))
+	}
+
+	if err := setKafkaResourceData(factory, d, kafka); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return diags
+	return diag.FromErr(failure)
 }
 
 func createACLForKafka(ctx context.Context, factory rhoasAPI.Factory, d *schema.ResourceData, kafka *kafkamgmtclient.KafkaRequest) error {
@@ -334,7 +576,7 @@ func createACLForKafka(ctx context.Context, factory rhoasAPI.Factory, d *schema.
 
 		// required for api, the user id, service account id or * works
 		// when appended to User:
-		principal = acl.PrincipalPrefix + principal
+		principal = acl.Principal(principal)
 
 		resourceType, ok := element[acl.ResourceTypeField].(string)
 		if !ok {
@@ -384,55 +626,28 @@ func createACLForKafka(ctx context.Context, factory rhoasAPI.Factory, d *schema.
 	return nil
 }
 
-func setResourceDataFromKafkaData(d *schema.ResourceData, kafka *kafkamgmtclient.KafkaRequest) error {
-	var err error
-
-	// any computed field is then set here
-	if err = d.Set(BillingCloudAccountIDField, kafka.GetBillingCloudAccountId()); err != nil {
-		return err
-	}
-
-	if err = d.Set(MarketPlaceField, kafka.GetMarketplace()); err != nil {
-		return err
-	}
-
-	if err = d.Set(HrefField, kafka.GetHref()); err != nil {
-		return err
-	}
-
-	if err = d.Set(StatusField, kafka.GetStatus()); err != nil {
-		return err
-	}
-
-	if err = d.Set(OwnerField, kafka.GetOwner()); err != nil {
-		return err
-	}
-
-	if err = d.Set(BootstrapServerHostField, kafka.GetBootstrapServerHost()); err != nil {
-		return err
-	}
-
-	if err = d.Set(CreatedAtField, kafka.GetCreatedAt().Format(time.RFC3339)); err != nil {
-		return err
-	}
-
-	if err = d.Set(UpdatedAtField, kafka.GetUpdatedAt().Format(time.RFC3339)); err != nil {
-		return err
-	}
-
-	if err = d.Set(IDField, kafka.GetId()); err != nil {
-		return err
+func setResourceDataFromKafkaData(d *schema.ResourceData, data map[string]interface{}) error {
+	for field, value := range data {
+		if err := d.Set(field, value); err != nil {
+			return err
+		}
 	}
 
-	if err = d.Set(KindField, kafka.GetKind()); err != nil {
-		return err
-	}
+	return nil
+}
 
-	if err = d.Set(VersionField, kafka.GetVersion()); err != nil {
-		return err
+// setKafkaResourceData sets the attributes of the rhoas_kafka resource from the
+// kafka instance returned by the api. The arguments which force a new instance
+// are kept as configured, as the api may return them in another form than the
+// configuration, e.g. a billing model it defaulted, which would plan to replace
+// the instance
+func setKafkaResourceData(factory rhoasAPI.Factory, d *schema.ResourceData, kafka *kafkamgmtclient.KafkaRequest) error {
+	data := mapKafkaToData(kafka, OAuthTokenEndpointURI(factory))
+	for _, field := range kafkaResourceArguments {
+		delete(data, field)
 	}
 
-	return nil
+	return setResourceDataFromKafkaData(d, data)
 }
 
 func mapResourceDataToKafkaPayload(factory rhoasAPI.Factory, d *schema.ResourceData) (*kafkamgmtclient.KafkaRequestPayload, error) {
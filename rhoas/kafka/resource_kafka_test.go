@@ -0,0 +1,277 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleKafkaCreationFailure(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	failure := errors.New("kafka instance \"test-kafka\" failed to be created: insufficient quota")
+
+	failedKafka := testKafkaRequest()
+	failedKafka.SetStatus("failed")
+	failedKafka.SetFailedReason("insufficient quota")
+
+	t.Run("taint keeps the failed instance", func(t *testing.T) {
+		factory := factories.NewDefaultFactory(factories.Options{
+			Localizer: localizer,
+		})
+		d := schema.TestResourceDataRaw(t, ResourceKafka(localizer).Schema, map[string]interface{}{})
+		d.SetId(failedKafka.GetId())
+
+		diags := handleKafkaCreationFailure(context.Background(), factory, d, failedKafka, failure)
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, failure.Error(), diags[0].Summary)
+		assert.Equal(t, failedKafka.GetId(), d.Id(), "expected the instance to be kept so it is tainted")
+		assert.Equal(t, "insufficient quota", d.Get(FailedReasonField))
+	})
+
+	t.Run("delete removes the failed instance", func(t *testing.T) {
+		var deleted bool
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				deleted = true
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+			HTTPClient: server.Client(),
+			BaseURL:    server.URL,
+		})
+		factory := factories.NewDefaultFactory(factories.Options{
+			KafkaClient: kafkaClient,
+			HTTPClient:  server.Client(),
+			Localizer:   localizer,
+		})
+		d := schema.TestResourceDataRaw(t, ResourceKafka(localizer).Schema, map[string]interface{}{
+			OnFailureField: OnFailureDelete,
+		})
+		d.SetId(failedKafka.GetId())
+
+		diags := handleKafkaCreationFailure(context.Background(), factory, d, failedKafka, failure)
+
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "insufficient quota")
+		assert.True(t, deleted, "expected the failed instance to be deleted")
+		assert.Equal(t, "", d.Id(), "expected the instance to be removed from the state")
+	})
+}
+
+// testKafkaStatusFactory returns a factory whose kafka instance goes through the given statuses
+func testKafkaStatusFactory(t *testing.T, statuses ...string) rhoasAPI.Factory {
+	localizer, _ := goi18n.New(nil)
+	reads := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kafka := testKafkaRequest()
+		kafka.SetStatus(statuses[reads])
+		if kafka.GetStatus() == "failed" {
+			kafka.SetFailedReason("region at capacity")
+		}
+		if reads < len(statuses)-1 {
+			reads++
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(kafka)
+	}))
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	return factories.NewDefaultFactory(factories.Options{
+		KafkaClient:     kafkaClient,
+		HTTPClient:      server.Client(),
+		Localizer:       localizer,
+		PollInterval:    time.Millisecond,
+		PollMaxInterval: time.Millisecond,
+	})
+}
+
+func TestWaitForKafkaReady(t *testing.T) {
+	t.Run("waits until the instance is ready", func(t *testing.T) {
+		factory := testKafkaStatusFactory(t, "accepted", "provisioning", "ready")
+
+		kafka, err := waitForKafkaReady(context.Background(), factory, "test-id", time.Minute)
+		assert.NoError(t, err, "unexpected error waiting for the kafka instance")
+		assert.Equal(t, "ready", kafka.GetStatus())
+	})
+
+	t.Run("returns the failed instance", func(t *testing.T) {
+		factory := testKafkaStatusFactory(t, "accepted", "failed")
+
+		kafka, err := waitForKafkaReady(context.Background(), factory, "test-id", time.Minute)
+		assert.Error(t, err, "expected an error for a failed kafka instance")
+		assert.Contains(t, err.Error(), "region at capacity", "expected the failed reason to be reported")
+		if assert.NotNil(t, kafka, "expected the failed instance to be returned") {
+			assert.Equal(t, "failed", kafka.GetStatus())
+		}
+	})
+}
+
+func TestKafkaACLCustomizeDiff(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		Localizer: localizer,
+	})
+
+	r := &schema.Resource{
+		Schema:        ResourceKafka(localizer).Schema,
+		CustomizeDiff: kafkaACLCustomizeDiff,
+	}
+
+	acls := []interface{}{map[string]interface{}{"principal": "*"}}
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "acls of a ready instance",
+			config: map[string]interface{}{ACLField: acls},
+		},
+		{
+			name:   "instance not waited for",
+			config: map[string]interface{}{WaitForReadyField: false},
+		},
+		{
+			name:    "acls of an instance not waited for",
+			config:  map[string]interface{}{ACLField: acls, WaitForReadyField: false},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				NameField:         "test-kafka",
+				PlanField:         "developer.x1",
+				BillingModelField: "standard",
+			}
+			for field, value := range tt.config {
+				config[field] = value
+			}
+
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), factory)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, WaitForReadyField)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKafkaCapacityCustomizeDiff(t *testing.T) {
+	factory := testServiceStatusFactory(t)
+
+	r := &schema.Resource{
+		Schema:        ResourceKafka(factory.Localizer()).Schema,
+		CustomizeDiff: kafkaCapacityCustomizeDiff,
+	}
+
+	existing := &terraform.InstanceState{
+		ID: "test-id",
+		Attributes: map[string]string{
+			IDField:                      "test-id",
+			NameField:                    "test-kafka",
+			CloudProviderField:           "aws",
+			RegionField:                  "us-east-1",
+			PlanField:                    "standard.x3",
+			BillingModelField:            "standard",
+			ReauthenticationEnabledField: "true",
+			CheckCapacityField:           "true",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		state   *terraform.InstanceState
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "available plan",
+			config: map[string]interface{}{PlanField: "standard.x1", CheckCapacityField: true},
+		},
+		{
+			name:    "plan at capacity",
+			config:  map[string]interface{}{PlanField: "standard.x3", CheckCapacityField: true},
+			wantErr: "at capacity",
+		},
+		{
+			name:    "plan not offered",
+			config:  map[string]interface{}{PlanField: "standard.x4", CheckCapacityField: true},
+			wantErr: "does not offer",
+		},
+		{
+			name:   "plan at capacity without checking the capacity",
+			config: map[string]interface{}{PlanField: "standard.x3"},
+		},
+		{
+			name:   "plan not offered without checking the capacity",
+			config: map[string]interface{}{PlanField: "standard.x4"},
+		},
+		{
+			name:   "existing instance",
+			state:  existing,
+			config: map[string]interface{}{PlanField: "standard.x3", CheckCapacityField: true},
+		},
+		{
+			name:    "instance replaced for its plan",
+			state:   existing,
+			config:  map[string]interface{}{PlanField: "standard.x4", CheckCapacityField: true},
+			wantErr: "does not offer",
+		},
+		{
+			name:    "instance replaced for its name",
+			state:   existing,
+			config:  map[string]interface{}{NameField: "other-kafka", PlanField: "standard.x3", CheckCapacityField: true},
+			wantErr: "at capacity",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				NameField:         "test-kafka",
+				BillingModelField: "standard",
+			}
+			for field, value := range tt.config {
+				config[field] = value
+			}
+
+			_, err := r.Diff(context.Background(), tt.state, terraform.NewResourceConfigRaw(config), factory)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
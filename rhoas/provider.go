@@ -2,21 +2,26 @@ package rhoas
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"log"
 	"os"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/acl"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/artifact"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connector"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/consumergroup"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	authAPI "github.com/redhat-developer/app-services-sdk-go/auth/apiv1"
-	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
-	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
-	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/kafka"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registry"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryrule"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/rolemapping"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/serviceaccount"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smartevents"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/topic"
 )
 
@@ -26,82 +31,142 @@ import (
 const (
 	DefaultAPIURL       = "https://api.openshift.com"
 	LocalDevelopmentEnv = "LOCAL_DEV"
+
+	OfflineTokenField    = "offline_token"
+	PollIntervalField    = "poll_interval"
+	PollMaxIntervalField = "poll_max_interval"
+
+	ValidateReferencesField = "validate_references"
+
+	OfflineTokenEnv       = "OFFLINE_TOKEN"
+	PollIntervalEnv       = "RHOAS_POLL_INTERVAL"
+	PollMaxIntervalEnv    = "RHOAS_POLL_MAX_INTERVAL"
+	ValidateReferencesEnv = "RHOAS_VALIDATE_REFERENCES"
+
+	DefaultPollInterval    = "5s"
+	DefaultPollMaxInterval = "30s"
+
+	// the longest wait between two polls of utils.StateChangeConf, which has no
+	// limit of its own, so that a resource becoming ready is noticed within minutes
+	maxPollInterval = 3*time.Minute - time.Second
 )
 
-// Provider -
+// the descriptions of the provider attributes, the schemas of both halves of
+// the provider must be identical
+var (
+	offlineTokenDescription       = "The offline token is a refresh token with no expiry and can be used by non-interactive processes to provide an access token for Red Hat OpenShift Application Services. The offline token can be obtained from [https://cloud.redhat.com/openshift/token](https://cloud.redhat.com/openshift/token). As the offline token is a sensitive value that varies between environments it is best specified using the `OFFLINE_TOKEN` environment variable."
+	pollIntervalDescription       = fmt.Sprintf("How long to wait before polling the status of a resource being created or deleted for the first time, e.g. `10s`. The wait doubles after every poll up to `poll_max_interval`. Defaults to `%s` and can be set with the `RHOAS_POLL_INTERVAL` environment variable.", DefaultPollInterval)
+	pollMaxIntervalDescription    = fmt.Sprintf("The longest wait between two polls of the status of a resource, e.g. `1m`. Defaults to `%s` and can be set with the `RHOAS_POLL_MAX_INTERVAL` environment variable.", DefaultPollMaxInterval)
+	validateReferencesDescription = "Whether to check during a plan that the Kafka instances referenced by the `kafka_id` of topics and ACLs, and the service accounts referenced by the principals of ACLs, exist and are accessible to your organization, instead of failing when applying. Each reference is looked up once per plan. Defaults to `false` and can be set with the `RHOAS_VALIDATE_REFERENCES` environment variable."
+)
+
+// Provider returns the sdk half of the provider, the resources migrated to the
+// plugin framework are served by FrameworkProvider
 func Provider() *schema.Provider {
 
 	localizer, err := goi18n.New(nil)
 	if err != nil {
-		return nil
+		log.Fatalf("unable to load the localized messages: %s", err)
 	}
 
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
-			"offline_token": {
+			OfflineTokenField: {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OFFLINE_TOKEN", nil),
-				Description: "The offline token is a refresh token with no expiry and can be used by non-interactive processes to provide an access token for Red Hat OpenShift Application Services. The offline token can be obtained from [https://cloud.redhat.com/openshift/token](https://cloud.redhat.com/openshift/token). As the offline token is a sensitive value that varies between environments it is best specified using the `OFFLINE_TOKEN` environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc(OfflineTokenEnv, nil),
+				Description: offlineTokenDescription,
+			},
+			PollIntervalField: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc(PollIntervalEnv, DefaultPollInterval),
+				ValidateDiagFunc: validatePollInterval,
+				Description:      pollIntervalDescription,
+			},
+			PollMaxIntervalField: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc(PollMaxIntervalEnv, DefaultPollMaxInterval),
+				ValidateDiagFunc: validatePollInterval,
+				Description:      pollMaxIntervalDescription,
+			},
+			ValidateReferencesField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(ValidateReferencesEnv, false),
+				Description: validateReferencesDescription,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"rhoas_kafka":           kafka.ResourceKafka(localizer),
-			"rhoas_topic":           topic.ResourceTopic(localizer),
-			"rhoas_service_account": serviceaccount.ResourceServiceAccount(localizer),
-			"rhoas_acl":             acl.ResourceACL(localizer),
+			"rhoas_kafka":                       kafka.ResourceKafka(localizer),
+			"rhoas_topic":                       topic.ResourceTopic(localizer),
+			"rhoas_topic_records":               topic.ResourceTopicRecords(localizer),
+			"rhoas_service_account":             serviceaccount.ResourceServiceAccount(localizer),
+			"rhoas_consumer_group":              consumergroup.ResourceConsumerGroup(localizer),
+			"rhoas_consumer_group_offset_reset": consumergroup.ResourceConsumerGroupOffsetReset(localizer),
+			"rhoas_service_registry":            registry.ResourceServiceRegistry(localizer),
+			"rhoas_registry_artifact":           artifact.ResourceRegistryArtifact(localizer),
+			"rhoas_registry_rule":               registryrule.ResourceRegistryRule(localizer),
+			"rhoas_registry_role_mapping":       rolemapping.ResourceRegistryRoleMapping(localizer),
+			"rhoas_connector_namespace":         connector.ResourceConnectorNamespace(localizer),
+			"rhoas_connector":                   connector.ResourceConnector(localizer),
+			"rhoas_bridge":                      smartevents.ResourceBridge(localizer),
+			"rhoas_processor":                   smartevents.ResourceProcessor(localizer),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"rhoas_kafkas":           kafka.DataSourceKafkas(localizer),
-			"rhoas_service_accounts": serviceaccount.DataSourceServiceAccounts(localizer),
-			"rhoas_kafka":            kafka.DataSourceKafka(localizer),
-			"rhoas_topic":            topic.DataSourceTopic(localizer),
-			"rhoas_service_account":  serviceaccount.DataSourceServiceAccount(localizer),
+			"rhoas_kafkas":             kafka.DataSourceKafkas(localizer),
+			"rhoas_service_accounts":   serviceaccount.DataSourceServiceAccounts(localizer),
+			"rhoas_kafka":              kafka.DataSourceKafka(localizer),
+			"rhoas_kafka_connection":   kafka.DataSourceKafkaConnection(localizer),
+			"rhoas_kafka_ready":        kafka.DataSourceKafkaReady(localizer),
+			"rhoas_kafka_metrics":      kafka.DataSourceKafkaMetrics(localizer),
+			"rhoas_service_status":     kafka.DataSourceServiceStatus(localizer),
+			"rhoas_kafka_quota":        kafka.DataSourceKafkaQuota(localizer),
+			"rhoas_topic":              topic.DataSourceTopic(localizer),
+			"rhoas_topic_records":      topic.DataSourceTopicRecords(localizer),
+			"rhoas_service_account":    serviceaccount.DataSourceServiceAccount(localizer),
+			"rhoas_consumer_group":     consumergroup.DataSourceConsumerGroup(localizer),
+			"rhoas_consumer_groups":    consumergroup.DataSourceConsumerGroups(localizer),
+			"rhoas_service_registry":   registry.DataSourceServiceRegistry(localizer),
+			"rhoas_service_registries": registry.DataSourceServiceRegistries(localizer),
+			"rhoas_connector_types":    connector.DataSourceConnectorTypes(localizer),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
 	localizer, err := goi18n.New(nil)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		os.Exit(1)
 	}
 
-	localDevelopmentServer := os.Getenv(LocalDevelopmentEnv)
+	offlineToken, _ := d.Get(OfflineTokenField).(string)
+	pollInterval, _ := d.Get(PollIntervalField).(string)
+	pollMaxInterval, _ := d.Get(PollMaxIntervalField).(string)
+	validateReferences, _ := d.Get(ValidateReferencesField).(bool)
 
-	httpClient := &http.Client{}
-	if localDevelopmentServer == "" {
-		// nolint: contextcheck
-		httpClient = authAPI.BuildAuthenticatedHTTPClient(d.Get("offline_token").(string))
+	config, err := newProviderConfig(offlineToken, pollInterval, pollMaxInterval, validateReferences)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
 
-	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
-		HTTPClient: httpClient,
-		BaseURL:    localDevelopmentServer, // will be ignored if not set
-	})
-
-	serviceAccountConfig := serviceAccounts.NewConfiguration()
+	return configureFactory(config, localizer), nil
+}
 
-	if localDevelopmentServer != "" {
-		serviceAccountConfig.Servers = serviceAccounts.ServerConfigurations{
-			{
-				URL:         localDevelopmentServer,
-				Description: "Local development",
-			},
-		}
+// validatePollInterval checks that the value is a duration which can be used
+// to poll the status of a resource
+func validatePollInterval(v interface{}, path cty.Path) diag.Diagnostics {
+	value, ok := v.(string)
+	if !ok {
+		return diag.Errorf("expected a string, got %T", v)
 	}
 
-	serviceAccountConfig.HTTPClient = httpClient
-	serviceAccountClient := serviceAccounts.NewAPIClient(serviceAccountConfig)
-
-	// package both service account client and kafka client together to be used in the provider
-	// these are passed to each action we do and can be use to CRUD kafkas/serviceAccounts
-	factory := factories.NewDefaultFactory(kafkaClient, serviceAccountClient, httpClient, localizer)
+	if _, err := parsePollInterval(value); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return factory, diags
+	return nil
 }
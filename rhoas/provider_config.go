@@ -0,0 +1,164 @@
+package rhoas
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authAPI "github.com/redhat-developer/app-services-sdk-go/auth/apiv1"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/accountmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/connectormgmt"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
+)
+
+// providerConfig is the configuration of the provider, read by both the sdk
+// and the plugin framework halves of the provider
+type providerConfig struct {
+	offlineToken           string
+	localDevelopmentServer string
+	pollInterval           time.Duration
+	pollMaxInterval        time.Duration
+	validateReferences     bool
+}
+
+var (
+	sharedFactoriesMu sync.Mutex
+	// the factories by configuration, shared by both halves of the provider
+	sharedFactories = map[providerConfig]*factories.DefaultFactory{}
+)
+
+// newProviderConfig returns the configuration of the provider from the values
+// of its attributes, the environment defaults having already been applied
+func newProviderConfig(offlineToken string, pollInterval string, pollMaxInterval string, validateReferences bool) (providerConfig, error) {
+	config := providerConfig{
+		offlineToken:           offlineToken,
+		localDevelopmentServer: os.Getenv(LocalDevelopmentEnv),
+		validateReferences:     validateReferences,
+	}
+
+	var err error
+	if config.pollInterval, err = parsePollInterval(pollInterval); err != nil {
+		return config, fmt.Errorf("%s: %w", PollIntervalField, err)
+	}
+
+	if config.pollMaxInterval, err = parsePollInterval(pollMaxInterval); err != nil {
+		return config, fmt.Errorf("%s: %w", PollMaxIntervalField, err)
+	}
+
+	if config.pollMaxInterval < config.pollInterval {
+		return config, fmt.Errorf("%s (%s) must not be shorter than %s (%s)", PollMaxIntervalField, config.pollMaxInterval, PollIntervalField, config.pollInterval)
+	}
+
+	return config, nil
+}
+
+// parsePollInterval returns the duration of the value, which must be usable to
+// poll the status of a resource
+func parsePollInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if interval <= 0 || interval > maxPollInterval {
+		return 0, fmt.Errorf("expected a positive duration of at most %s, got %s", maxPollInterval, value)
+	}
+
+	return interval, nil
+}
+
+// configureFactory returns the factory for the configuration. Terraform
+// configures both halves of the provider with the same configuration, so the
+// factory built for the first half is shared with the second one. Each
+// configuration, e.g. of an aliased provider block, gets a factory of its own
+func configureFactory(config providerConfig, localizer localize.Localizer) *factories.DefaultFactory {
+	sharedFactoriesMu.Lock()
+	defer sharedFactoriesMu.Unlock()
+
+	factory, ok := sharedFactories[config]
+	if !ok {
+		factory = newFactory(config, localizer)
+		sharedFactories[config] = factory
+	}
+
+	return factory
+}
+
+// newFactory builds the clients of the apis for the configuration
+func newFactory(config providerConfig, localizer localize.Localizer) *factories.DefaultFactory {
+	localDevelopmentServer := config.localDevelopmentServer
+
+	httpClient := &http.Client{}
+	if localDevelopmentServer == "" {
+		// nolint: contextcheck
+		httpClient = authAPI.BuildAuthenticatedHTTPClient(config.offlineToken)
+	}
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: httpClient,
+		BaseURL:    localDevelopmentServer, // will be ignored if not set
+	})
+
+	serviceAccountConfig := serviceAccounts.NewConfiguration()
+
+	if localDevelopmentServer != "" {
+		serviceAccountConfig.Servers = serviceAccounts.ServerConfigurations{
+			{
+				URL:         localDevelopmentServer,
+				Description: "Local development",
+			},
+		}
+	}
+
+	serviceAccountConfig.HTTPClient = httpClient
+	serviceAccountClient := serviceAccounts.NewAPIClient(serviceAccountConfig)
+
+	apiURL := DefaultAPIURL
+	authURL := authAPI.DefaultAuthURL
+	if localDevelopmentServer != "" {
+		apiURL = localDevelopmentServer
+		authURL = localAuthURL(localDevelopmentServer)
+	}
+
+	accountMgmtClient := accountmgmt.NewAPIClient(httpClient, apiURL)
+	registryMgmtClient := registrymgmt.NewAPIClient(httpClient, apiURL)
+	connectorMgmtClient := connectormgmt.NewAPIClient(httpClient, apiURL)
+	smartEventsClient := smarteventsmgmt.NewAPIClient(httpClient, apiURL)
+
+	// package both service account client and kafka client together to be used in the provider
+	// these are passed to each action we do and can be use to CRUD kafkas/serviceAccounts
+	return factories.NewDefaultFactory(factories.Options{
+		KafkaClient:          kafkaClient,
+		ServiceAccountClient: serviceAccountClient,
+		AccountMgmtClient:    accountMgmtClient,
+		RegistryMgmtClient:   registryMgmtClient,
+		ConnectorMgmtClient:  connectorMgmtClient,
+		SmartEventsClient:    smartEventsClient,
+		HTTPClient:           httpClient,
+		AuthURL:              authURL,
+		Localizer:            localizer,
+		PollInterval:         config.pollInterval,
+		PollMaxInterval:      config.pollMaxInterval,
+		ValidateReferences:   config.validateReferences,
+	})
+}
+
+// localAuthURL returns the url of the realm of the sso server on the local
+// development server, which serves it under the same path as the sso server
+func localAuthURL(localDevelopmentServer string) string {
+	authURL, err := url.Parse(authAPI.DefaultAuthURL)
+	if err != nil {
+		return localDevelopmentServer
+	}
+
+	return strings.TrimSuffix(localDevelopmentServer, "/") + authURL.Path
+}
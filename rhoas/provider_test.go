@@ -2,11 +2,21 @@ package rhoas_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/redhat-developer/terraform-provider-rhoas/rhoas"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,15 +39,22 @@ func TestProviderConfigureLocalServer(t *testing.T) {
 	os.Setenv(rhoas.LocalDevelopmentEnv, "http://localhost:8000")
 	defer os.Setenv(rhoas.LocalDevelopmentEnv, "")
 
-	diag := rhoas.Provider().Configure(context.TODO(), &terraform.ResourceConfig{})
+	p := rhoas.Provider()
+	diag := p.Configure(context.TODO(), &terraform.ResourceConfig{})
 	assert.Empty(t, diag, "got unexpected diagnostics")
+
+	factory, ok := p.Meta().(rhoasAPI.Factory)
+	if !ok {
+		t.Fatalf("expected the provider to be configured with a factory, got %T", p.Meta())
+	}
+	assert.Equal(t, "http://localhost:8000/auth/realms/redhat-external", factory.AuthURL(), "expected the sso realm to be served by the local server")
 }
 
 // TestProviderSchema checks that the RHOAS provider schema is the expected one
 func TestProviderSchema(t *testing.T) {
 	schemaRequest := terraform.ProviderSchemaRequest{
-		ResourceTypes: []string{"rhoas_kafka", "rhoas_topic", "rhoas_service_account", "rhoas_acl"},
-		DataSources:   []string{"rhoas_kafka", "rhoas_topic", "rhoas_service_account"},
+		ResourceTypes: []string{"rhoas_kafka", "rhoas_topic", "rhoas_topic_records", "rhoas_service_account", "rhoas_consumer_group", "rhoas_consumer_group_offset_reset", "rhoas_service_registry", "rhoas_registry_artifact", "rhoas_registry_rule", "rhoas_registry_role_mapping", "rhoas_connector_namespace", "rhoas_connector", "rhoas_bridge", "rhoas_processor"},
+		DataSources:   []string{"rhoas_kafka", "rhoas_kafka_connection", "rhoas_kafka_ready", "rhoas_kafka_metrics", "rhoas_service_status", "rhoas_kafka_quota", "rhoas_topic", "rhoas_topic_records", "rhoas_service_account", "rhoas_consumer_group", "rhoas_service_registry"},
 	}
 
 	providerSchema, err := rhoas.Provider().GetSchema(&schemaRequest)
@@ -62,3 +79,327 @@ func TestProviderSchema(t *testing.T) {
 		assert.Contains(t, sut, "offline_token")
 	})
 }
+
+// TestProviderServerSchema checks that both halves of the provider have the
+// same provider schema, the mux server reporting a diagnostic otherwise, and
+// that every resource type is served by one of them
+func TestProviderServerSchema(t *testing.T) {
+	providerServer, err := rhoas.ProviderServer(context.Background())
+	if !assert.NoError(t, err, "unexpected error creating the provider server") {
+		return
+	}
+
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	assert.NoError(t, err, "unexpected error getting the provider schema")
+	assert.Empty(t, resp.Diagnostics, "got unexpected diagnostics")
+
+	assert.Contains(t, resp.ResourceSchemas, "rhoas_acl")
+	assert.Contains(t, resp.ResourceSchemas, "rhoas_kafka")
+	assert.Contains(t, resp.DataSourceSchemas, "rhoas_kafka")
+	assert.Contains(t, resp.EphemeralResourceSchemas, "rhoas_service_account_credentials")
+}
+
+// configureSDKProvider returns the factory of the sdk half of the provider
+// configured with the given poll interval
+func configureSDKProvider(t *testing.T, pollInterval string) interface{} {
+	sdkProvider := rhoas.Provider()
+	diag := sdkProvider.Configure(context.TODO(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		rhoas.PollIntervalField: pollInterval,
+	}))
+	assert.Empty(t, diag, "got unexpected diagnostics")
+
+	return sdkProvider.Meta()
+}
+
+// configureFrameworkProvider returns the factory of the plugin framework half
+// of the provider configured with the given poll interval
+func configureFrameworkProvider(t *testing.T, pollInterval string) interface{} {
+	frameworkProvider := rhoas.FrameworkProvider()
+
+	schemaResp := &provider.SchemaResponse{}
+	frameworkProvider.Schema(context.TODO(), provider.SchemaRequest{}, schemaResp)
+
+	config := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.TODO()), map[string]tftypes.Value{
+		rhoas.OfflineTokenField:       tftypes.NewValue(tftypes.String, nil),
+		rhoas.PollIntervalField:       tftypes.NewValue(tftypes.String, pollInterval),
+		rhoas.PollMaxIntervalField:    tftypes.NewValue(tftypes.String, nil),
+		rhoas.ValidateReferencesField: tftypes.NewValue(tftypes.Bool, nil),
+	})
+
+	resp := &provider.ConfigureResponse{}
+	frameworkProvider.Configure(context.TODO(), provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}, resp)
+	assert.Empty(t, resp.Diagnostics, "got unexpected diagnostics")
+
+	return resp.ResourceData
+}
+
+// TestProviderConfigureSharedFactory checks that both halves of the provider
+// share the same factory when configured with the same configuration
+func TestProviderConfigureSharedFactory(t *testing.T) {
+	os.Setenv(rhoas.LocalDevelopmentEnv, "http://localhost:8000")
+	defer os.Setenv(rhoas.LocalDevelopmentEnv, "")
+
+	sdkFactory := configureSDKProvider(t, "1s")
+	frameworkFactory := configureFrameworkProvider(t, "1s")
+
+	assert.Same(t, sdkFactory, frameworkFactory, "expected the factory of the sdk half to be shared")
+}
+
+// TestProviderConfigureAliasedFactories checks that the halves of aliased
+// provider blocks share the factory of their own configuration, whatever the
+// order in which they are configured
+func TestProviderConfigureAliasedFactories(t *testing.T) {
+	os.Setenv(rhoas.LocalDevelopmentEnv, "http://localhost:8000")
+	defer os.Setenv(rhoas.LocalDevelopmentEnv, "")
+
+	sdkFactory := configureSDKProvider(t, "2s")
+	aliasedSDKFactory := configureSDKProvider(t, "3s")
+	frameworkFactory := configureFrameworkProvider(t, "2s")
+	aliasedFrameworkFactory := configureFrameworkProvider(t, "3s")
+
+	assert.Same(t, sdkFactory, frameworkFactory, "expected the factory of the provider block to be shared")
+	assert.Same(t, aliasedSDKFactory, aliasedFrameworkFactory, "expected the factory of the aliased provider block to be shared")
+	assert.NotSame(t, sdkFactory, aliasedSDKFactory, "expected each provider block to get a factory of its own")
+}
+
+// TestProviderServerACLState checks that the states of rhoas_acl written by
+// the sdk resource are read by the plugin framework resource
+func TestProviderServerACLState(t *testing.T) {
+	providerServer, err := rhoas.ProviderServer(context.Background())
+	if !assert.NoError(t, err, "unexpected error creating the provider server") {
+		return
+	}
+
+	server := providerServer()
+
+	sdkState := []byte(`{"id":"test-kafka-id42","kafka_id":"test-kafka-id","principal":"jdoe","resource_type":"TOPIC","resource_name":"orders","pattern_type":"LITERAL","operation_type":"READ","permission_type":"ALLOW","timeouts":null}`)
+
+	upgradeResp, err := server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "rhoas_acl",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: sdkState},
+	})
+	assert.NoError(t, err, "unexpected error upgrading the state")
+	if !assert.Empty(t, upgradeResp.Diagnostics, "got unexpected diagnostics") {
+		return
+	}
+
+	readResp, err := server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{
+		TypeName:     "rhoas_acl",
+		CurrentState: upgradeResp.UpgradedState,
+		Private:      []byte(`{"e2bfb730-ecaa-11e6-8f88-34363bc7c4c0":{"create":1200000000000},"schema_version":"0"}`),
+	})
+	assert.NoError(t, err, "unexpected error reading the resource")
+	assert.Empty(t, readResp.Diagnostics, "got unexpected diagnostics")
+	assert.Equal(t, upgradeResp.UpgradedState, readResp.NewState, "expected the state to be kept")
+}
+
+// TestProviderServerServiceAccountCredentials checks that the secret of the
+// service account is reset when rhoas_service_account_credentials is opened,
+// and reset again when closed to revoke it
+func TestProviderServerServiceAccountCredentials(t *testing.T) {
+	var resets int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/service_accounts/v1/test-sa/resetSecret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		reset := atomic.AddInt32(&resets, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "test-sa", "clientId": "test-client-id", "secret": fmt.Sprintf("secret-%d", reset)})
+	}))
+	defer api.Close()
+
+	os.Setenv(rhoas.LocalDevelopmentEnv, api.URL)
+	defer os.Setenv(rhoas.LocalDevelopmentEnv, "")
+
+	providerServer, err := rhoas.ProviderServer(context.Background())
+	if !assert.NoError(t, err, "unexpected error creating the provider server") {
+		return
+	}
+
+	server := providerServer()
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	assert.NoError(t, err, "unexpected error getting the provider schema")
+
+	providerType := schemaResp.Provider.ValueType()
+	providerConfig, err := tfprotov5.NewDynamicValue(providerType, tftypes.NewValue(providerType, map[string]tftypes.Value{
+		rhoas.OfflineTokenField:       tftypes.NewValue(tftypes.String, nil),
+		rhoas.PollIntervalField:       tftypes.NewValue(tftypes.String, nil),
+		rhoas.PollMaxIntervalField:    tftypes.NewValue(tftypes.String, nil),
+		rhoas.ValidateReferencesField: tftypes.NewValue(tftypes.Bool, nil),
+	}))
+	assert.NoError(t, err)
+
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	assert.NoError(t, err, "unexpected error configuring the provider")
+	assert.Empty(t, configureResp.Diagnostics, "got unexpected diagnostics")
+
+	credentialsType := schemaResp.EphemeralResourceSchemas["rhoas_service_account_credentials"].ValueType()
+	credentialsConfig, err := tfprotov5.NewDynamicValue(credentialsType, tftypes.NewValue(credentialsType, map[string]tftypes.Value{
+		"service_account_id": tftypes.NewValue(tftypes.String, "test-sa"),
+		"revoke_on_close":    tftypes.NewValue(tftypes.Bool, true),
+		"client_id":          tftypes.NewValue(tftypes.String, nil),
+		"client_secret":      tftypes.NewValue(tftypes.String, nil),
+	}))
+	assert.NoError(t, err)
+
+	openResp, err := server.OpenEphemeralResource(context.Background(), &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "rhoas_service_account_credentials",
+		Config:   &credentialsConfig,
+	})
+	assert.NoError(t, err, "unexpected error opening the ephemeral resource")
+	if !assert.Empty(t, openResp.Diagnostics, "got unexpected diagnostics") {
+		return
+	}
+
+	result, err := openResp.Result.Unmarshal(credentialsType)
+	assert.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	assert.NoError(t, result.As(&attributes))
+
+	var clientID, clientSecret string
+	assert.NoError(t, attributes["client_id"].As(&clientID))
+	assert.NoError(t, attributes["client_secret"].As(&clientSecret))
+	assert.Equal(t, "test-client-id", clientID)
+	assert.Equal(t, "secret-1", clientSecret)
+
+	closeResp, err := server.CloseEphemeralResource(context.Background(), &tfprotov5.CloseEphemeralResourceRequest{
+		TypeName: "rhoas_service_account_credentials",
+		Private:  openResp.Private,
+	})
+	assert.NoError(t, err, "unexpected error closing the ephemeral resource")
+	assert.Empty(t, closeResp.Diagnostics, "got unexpected diagnostics")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&resets), "expected the secret to be reset when opened and closed")
+}
+
+// TestProviderServerFunctions checks that the provider functions are served
+// and return the values computed by the helpers of the resources
+func TestProviderServerFunctions(t *testing.T) {
+	providerServer, err := rhoas.ProviderServer(context.Background())
+	if !assert.NoError(t, err, "unexpected error creating the provider server") {
+		return
+	}
+
+	server := providerServer()
+
+	// the arguments are strings, or numbers for ints and null numbers for nil
+	dynamicArgument := func(value interface{}) *tfprotov5.DynamicValue {
+		var argumentValue tftypes.Value
+		switch v := value.(type) {
+		case string:
+			argumentValue = tftypes.NewValue(tftypes.String, v)
+		case int:
+			argumentValue = tftypes.NewValue(tftypes.Number, v)
+		default:
+			argumentValue = tftypes.NewValue(tftypes.Number, nil)
+		}
+
+		argument, err := tfprotov5.NewDynamicValue(argumentValue.Type(), argumentValue)
+		assert.NoError(t, err)
+
+		return &argument
+	}
+
+	planType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"instance_type": tftypes.String, "size": tftypes.String}}
+
+	tests := []struct {
+		name       string
+		arguments  []interface{}
+		resultType tftypes.Type
+		want       tftypes.Value
+		wantError  bool
+	}{
+		{
+			name:       "principal",
+			arguments:  []interface{}{"srvc-acct-8c2a5d3e-1b7f-4c7e-9a51-3f0c2e6d4b19"},
+			resultType: tftypes.String,
+			want:       tftypes.NewValue(tftypes.String, "User:srvc-acct-8c2a5d3e-1b7f-4c7e-9a51-3f0c2e6d4b19"),
+		},
+		{
+			name:       "acl_id",
+			arguments:  []interface{}{"test-kafka-id", "jdoe", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"},
+			resultType: tftypes.String,
+			want:       tftypes.NewValue(tftypes.String, "test-kafka-id/User:jdoe/TOPIC/orders/LITERAL/READ/ALLOW"),
+		},
+		{
+			name:       "valid_topic_name",
+			arguments:  []interface{}{"orders/eu"},
+			resultType: tftypes.Bool,
+			want:       tftypes.NewValue(tftypes.Bool, false),
+		},
+		{
+			name:       "valid_kafka_name",
+			arguments:  []interface{}{"orders-eu"},
+			resultType: tftypes.Bool,
+			want:       tftypes.NewValue(tftypes.Bool, true),
+		},
+		{
+			name:       "parse_plan",
+			arguments:  []interface{}{"standard.x2"},
+			resultType: planType,
+			want: tftypes.NewValue(planType, map[string]tftypes.Value{
+				"instance_type": tftypes.NewValue(tftypes.String, "standard"),
+				"size":          tftypes.NewValue(tftypes.String, "x2"),
+			}),
+		},
+		{
+			name:      "parse_plan",
+			arguments: []interface{}{"standard"},
+			wantError: true,
+		},
+		{
+			name:       "bootstrap_url",
+			arguments:  []interface{}{"test-kafka.example.com:443", 9096},
+			resultType: tftypes.String,
+			want:       tftypes.NewValue(tftypes.String, "test-kafka.example.com:9096"),
+		},
+		{
+			name:       "bootstrap_url",
+			arguments:  []interface{}{"test-kafka.example.com", nil},
+			resultType: tftypes.String,
+			want:       tftypes.NewValue(tftypes.String, "test-kafka.example.com:443"),
+		},
+		{
+			name:      "bootstrap_url",
+			arguments: []interface{}{"test-kafka.example.com", 0},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			arguments := make([]*tfprotov5.DynamicValue, 0, len(tt.arguments))
+			for _, argument := range tt.arguments {
+				arguments = append(arguments, dynamicArgument(argument))
+			}
+
+			resp, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+				Name:      tt.name,
+				Arguments: arguments,
+			})
+			assert.NoError(t, err, "unexpected error calling the function")
+
+			if tt.wantError {
+				assert.NotNil(t, resp.Error, "expected an error")
+				return
+			}
+
+			if !assert.Nil(t, resp.Error, "got unexpected error") {
+				return
+			}
+
+			result, err := resp.Result.Unmarshal(tt.resultType)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(result), "expected %s, got %s", tt.want, result)
+		})
+	}
+}
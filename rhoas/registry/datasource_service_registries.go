@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RegistriesField = "registries"
+)
+
+func DataSourceServiceRegistries(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_service_registries` provides a list of the Service Registry instances accessible to your organization in Red Hat OpenShift Service Registry.",
+		ReadContext: dataSourceServiceRegistriesRead,
+		Schema: map[string]*schema.Schema{
+			NameField: {
+				Description: localizer.MustLocalize("registry.datasource.field.description.filterName"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			RegistriesField: {
+				Description: localizer.MustLocalize("registry.datasource.field.description.registries"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourceRegistrySchema(localizer),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServiceRegistriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField)))
+	}
+
+	registries, resp, err := factory.RegistryMgmt().ListRegistries(ctx, buildRegistriesSearchQuery(name))
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = d.Set(RegistriesField, flattenRegistries(registries)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// use the current timestamp for a list request to force a refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// buildRegistriesSearchQuery converts the name filter into a search expression
+// for the registry list api, using "like" when the name contains a "%" wildcard
+func buildRegistriesSearchQuery(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	operator := "="
+	if strings.Contains(name, "%") {
+		operator = "like"
+	}
+
+	return fmt.Sprintf("%s %s %s", NameField, operator, name)
+}
+
+func flattenRegistries(registries []registrymgmt.Registry) []interface{} {
+	rs := make([]interface{}, len(registries))
+
+	for i := range registries {
+		rs[i] = mapRegistryToData(&registries[i])
+	}
+
+	return rs
+}
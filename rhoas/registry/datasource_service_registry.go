@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+func DataSourceServiceRegistry(localizer localize.Localizer) *schema.Resource {
+	registrySchema := dataSourceRegistrySchema(localizer)
+
+	// the registry can be read by either its id or name
+	registrySchema[IDField] = &schema.Schema{
+		Description:  localizer.MustLocalize("registry.datasource.field.description.id"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{IDField, NameField},
+	}
+	registrySchema[NameField] = &schema.Schema{
+		Description:  localizer.MustLocalize("registry.datasource.field.description.name"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{IDField, NameField},
+	}
+
+	return &schema.Resource{
+		Description: "`rhoas_service_registry` provides a Service Registry instance accessible to your organization in Red Hat OpenShift Service Registry.",
+		ReadContext: dataSourceServiceRegistryRead,
+		Schema:      registrySchema,
+	}
+}
+
+func dataSourceServiceRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	id, ok := d.Get(IDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", IDField)))
+	}
+
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField)))
+	}
+
+	var registry registrymgmt.Registry
+	var err error
+
+	if id != "" {
+		registry, err = getRegistryByID(ctx, factory, id)
+	} else {
+		registry, err = getRegistryByName(ctx, factory, name)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(registry.ID)
+
+	if err = setResourceDataFromRegistry(d, &registry); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func getRegistryByID(ctx context.Context, factory rhoasAPI.Factory, id string) (registrymgmt.Registry, error) {
+	registry, resp, err := factory.RegistryMgmt().GetRegistry(ctx, id)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return registrymgmt.Registry{}, apiErr
+	}
+
+	return registry, nil
+}
+
+// getRegistryByName looks up the registry with the given name, failing if
+// there is no registry or more than one registry with that name
+func getRegistryByName(ctx context.Context, factory rhoasAPI.Factory, name string) (registrymgmt.Registry, error) {
+	registries, resp, err := factory.RegistryMgmt().ListRegistries(ctx, fmt.Sprintf("%s = %s", NameField, name))
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return registrymgmt.Registry{}, apiErr
+	}
+
+	switch len(registries) {
+	case 0:
+		return registrymgmt.Registry{}, factory.Localizer().MustLocalizeError("registry.errors.notFoundByName", localize.NewEntry("Name", name))
+	case 1:
+		return registries[0], nil
+	default:
+		return registrymgmt.Registry{}, factory.Localizer().MustLocalizeError("registry.errors.multipleFoundByName", localize.NewEntry("Name", name), localize.NewEntry("Count", len(registries)))
+	}
+}
@@ -0,0 +1,170 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	IDField           = "id"
+	NameField         = "name"
+	DescriptionField  = "description"
+	StatusField       = "status"
+	RegistryURLField  = "registry_url"
+	BrowserURLField   = "browser_url"
+	OwnerField        = "owner"
+	InstanceTypeField = "instance_type"
+	CreatedAtField    = "created_at"
+	UpdatedAtField    = "updated_at"
+
+	StatusReady  = "ready"
+	StatusFailed = "failed"
+)
+
+// withComputedRegistrySchema adds the attributes which are only ever read from
+// the api to the given schema. These are shared by the rhoas_service_registry
+// resource and the rhoas_service_registry and rhoas_service_registries data sources
+func withComputedRegistrySchema(localizer localize.Localizer, s map[string]*schema.Schema) map[string]*schema.Schema {
+	computed := map[string]*schema.Schema{
+		IDField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.id"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		StatusField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.status"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		RegistryURLField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.registryURL"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		BrowserURLField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.browserURL"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		OwnerField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.owner"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		InstanceTypeField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.instanceType"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		CreatedAtField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.createdAt"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		UpdatedAtField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.updatedAt"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	for field, fieldSchema := range s {
+		computed[field] = fieldSchema
+	}
+
+	return computed
+}
+
+// dataSourceRegistrySchema returns the schema of a registry as read by the data sources
+func dataSourceRegistrySchema(localizer localize.Localizer) map[string]*schema.Schema {
+	return withComputedRegistrySchema(localizer, map[string]*schema.Schema{
+		NameField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.name"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		DescriptionField: {
+			Description: localizer.MustLocalize("registry.resource.field.description.description"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	})
+}
+
+// mapRegistryToData maps a registry returned by the api to the attributes of
+// the rhoas_service_registry resource and data sources
+func mapRegistryToData(registry *registrymgmt.Registry) map[string]interface{} {
+	return map[string]interface{}{
+		IDField:           registry.ID,
+		NameField:         registry.Name,
+		DescriptionField:  registry.Description,
+		StatusField:       registry.Status,
+		RegistryURLField:  registry.RegistryURL,
+		BrowserURLField:   registry.BrowserURL,
+		OwnerField:        registry.Owner,
+		InstanceTypeField: registry.InstanceType,
+		CreatedAtField:    registry.CreatedAt,
+		UpdatedAtField:    registry.UpdatedAt,
+	}
+}
+
+func setResourceDataFromRegistry(d *schema.ResourceData, registry *registrymgmt.Registry) error {
+	for field, value := range mapRegistryToData(registry) {
+		if field == IDField {
+			continue
+		}
+
+		if err := d.Set(field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForRegistryReady waits until the registry with the given id is ready.
+// When the registry fails it is returned along with the error
+func waitForRegistryReady(ctx context.Context, factory rhoasAPI.Factory, id string, timeout time.Duration) (*registrymgmt.Registry, error) {
+	var failedRegistry *registrymgmt.Registry
+
+	readyStateConf := utils.NewStateChangeConf(
+		[]string{
+			"accepted",
+			"provisioning",
+		},
+		[]string{
+			StatusReady,
+		},
+		func() (interface{}, string, error) {
+			registry, resp, err := factory.RegistryMgmt().GetRegistry(ctx, id)
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			if registry.Status == StatusFailed {
+				failedRegistry = &registry
+				return registry, registry.Status, factory.Localizer().MustLocalizeError("registry.errors.creationFailed", localize.NewEntry("Name", registry.Name))
+			}
+
+			return registry, registry.Status, nil
+		},
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	data, err := readyStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return failedRegistry, err
+	}
+
+	registry, _ := data.(registrymgmt.Registry)
+
+	return &registry, nil
+}
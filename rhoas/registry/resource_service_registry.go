@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+func ResourceServiceRegistry(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_service_registry` manages a Service Registry instance in Red Hat OpenShift Service Registry.",
+		CreateContext: serviceRegistryCreate,
+		ReadContext:   serviceRegistryRead,
+		DeleteContext: serviceRegistryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: withComputedRegistrySchema(localizer, map[string]*schema.Schema{
+			NameField: {
+				Description: localizer.MustLocalize("registry.resource.field.description.name"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			DescriptionField: {
+				Description: localizer.MustLocalize("registry.resource.field.description.description"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+		}),
+	}
+}
+
+func serviceRegistryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField)))
+	}
+
+	description, ok := d.Get(DescriptionField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", DescriptionField)))
+	}
+
+	registry, resp, err := factory.RegistryMgmt().CreateRegistry(ctx, registrymgmt.RegistryCreate{
+		Name:        name,
+		Description: description,
+	})
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(registry.ID)
+
+	ready, err := waitForRegistryReady(ctx, factory, registry.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		if ready != nil {
+			// keep the failed registry in the state so it is tainted and replaced
+			if setErr := setResourceDataFromRegistry(d, ready); setErr != nil {
+				return diag.FromErr(setErr)
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = setResourceDataFromRegistry(d, ready); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func serviceRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registry, resp, err := factory.RegistryMgmt().GetRegistry(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the registry was deleted outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = setResourceDataFromRegistry(d, &registry); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func serviceRegistryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	resp, err := factory.RegistryMgmt().DeleteRegistry(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	deleteStateConf := utils.NewStateChangeConf(
+		[]string{
+			StatusReady, "deprovision", "deleting",
+		},
+		[]string{
+			"deleted",
+		},
+		func() (interface{}, string, error) {
+			registry, resp, err := factory.RegistryMgmt().GetRegistry(ctx, d.Id())
+			if resp != nil && utils.CheckNotFound(resp) {
+				return registry, "deleted", nil
+			}
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			return registry, registry.Status, nil
+		},
+		d.Timeout(schema.TimeoutDelete),
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	if _, err = deleteStateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registrymgmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRegistryMgmt is an in memory registry management api. Every read of a
+// registry advances it to its next status until it is ready, deleted
+// registries are gone after the first read
+type fakeRegistryMgmt struct {
+	registries map[string]*registrymgmt.Registry
+	statuses   map[string][]string
+}
+
+func (f *fakeRegistryMgmt) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const basePath = "/api/serviceregistry_mgmt/v1/registries"
+
+	writeJSON := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	notFound := map[string]string{"code": "SRS-MGMT-2", "reason": "registry not found"}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == basePath:
+		var create registrymgmt.RegistryCreate
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		registry := &registrymgmt.Registry{ID: "registry-" + create.Name, Name: create.Name, Description: create.Description, Status: "accepted"}
+		f.registries[registry.ID] = registry
+		writeJSON(http.StatusAccepted, registry)
+	case r.Method == http.MethodGet && r.URL.Path == basePath:
+		items := make([]registrymgmt.Registry, 0)
+		for _, registry := range f.registries {
+			if search := r.URL.Query().Get("search"); search == "" || search == "name = "+registry.Name {
+				items = append(items, *registry)
+			}
+		}
+		writeJSON(http.StatusOK, registrymgmt.RegistryList{Page: 1, Size: len(items), Total: len(items), Items: items})
+	default:
+		registry, ok := f.registries[r.URL.Path[len(basePath)+1:]]
+		if !ok {
+			writeJSON(http.StatusNotFound, notFound)
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			registry.Status = "deleting"
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if registry.Status == "deleting" {
+			delete(f.registries, registry.ID)
+		} else if statuses := f.statuses[registry.Name]; len(statuses) > 0 {
+			registry.Status, f.statuses[registry.Name] = statuses[0], statuses[1:]
+		}
+		registry.RegistryURL = "https://registry.example.com/t/" + registry.ID
+		writeJSON(http.StatusOK, registry)
+	}
+}
+
+// testRegistryMgmtFactory returns a factory whose registry management api is
+// a local fake, along with the fake to inspect its registries
+func testRegistryMgmtFactory(t *testing.T, statuses map[string][]string) (rhoasAPI.Factory, *fakeRegistryMgmt) {
+	localizer, _ := goi18n.New(nil)
+
+	fake := &fakeRegistryMgmt{registries: map[string]*registrymgmt.Registry{}, statuses: statuses}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return factories.NewDefaultFactory(factories.Options{
+		RegistryMgmtClient: registrymgmt.NewAPIClient(server.Client(), server.URL),
+		HTTPClient:         server.Client(),
+		Localizer:          localizer,
+	}), fake
+}
+
+func TestResourceServiceRegistryLifecycle(t *testing.T) {
+	factory, fake := testRegistryMgmtFactory(t, map[string][]string{
+		"test-registry": {"provisioning", "ready"},
+	})
+
+	r := ResourceServiceRegistry(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField:        "test-registry",
+		DescriptionField: "a test registry",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "registry-test-registry", d.Id())
+	assert.Equal(t, StatusReady, d.Get(StatusField))
+	assert.Equal(t, "https://registry.example.com/t/registry-test-registry", d.Get(RegistryURLField))
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.registries)
+}
+
+func TestResourceServiceRegistryCreationFailure(t *testing.T) {
+	factory, _ := testRegistryMgmtFactory(t, map[string][]string{
+		"test-registry": {"provisioning", StatusFailed},
+	})
+
+	r := ResourceServiceRegistry(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField: "test-registry",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "registry-test-registry", d.Id(), "expected the failed registry to be kept so it is tainted")
+	assert.Equal(t, StatusFailed, d.Get(StatusField))
+}
+
+func TestResourceServiceRegistryReadDeleted(t *testing.T) {
+	factory, _ := testRegistryMgmtFactory(t, nil)
+
+	r := ResourceServiceRegistry(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("deleted-registry")
+
+	diags := r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id(), "expected the registry deleted outside of terraform to be removed from the state")
+}
+
+func TestDataSourceServiceRegistryRead(t *testing.T) {
+	factory, fake := testRegistryMgmtFactory(t, nil)
+	fake.registries["registry-1"] = &registrymgmt.Registry{ID: "registry-1", Name: "first", Status: StatusReady, RegistryURL: "https://registry.example.com/t/registry-1"}
+	fake.registries["registry-2"] = &registrymgmt.Registry{ID: "registry-2", Name: "second", Status: StatusReady, RegistryURL: "https://registry.example.com/t/registry-2"}
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantID  string
+		wantErr bool
+	}{
+		{name: "by id", config: map[string]interface{}{IDField: "registry-2"}, wantID: "registry-2"},
+		{name: "by name", config: map[string]interface{}{NameField: "first"}, wantID: "registry-1"},
+		{name: "unknown name", config: map[string]interface{}{NameField: "third"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := DataSourceServiceRegistry(factory.Localizer())
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.config) // nolint:scopelint
+
+			diags := r.ReadContext(context.Background(), d, factory)
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags) // nolint:scopelint
+			if !tt.wantErr {                                     // nolint:scopelint
+				assert.Equal(t, tt.wantID, d.Id()) // nolint:scopelint
+				assert.NotEmpty(t, d.Get(RegistryURLField))
+			}
+		})
+	}
+}
+
+func TestBuildRegistriesSearchQuery(t *testing.T) {
+	assert.Equal(t, "", buildRegistriesSearchQuery(""))
+	assert.Equal(t, "name = my-registry", buildRegistriesSearchQuery("my-registry"))
+	assert.Equal(t, "name like my-%", buildRegistriesSearchQuery("my-%"))
+}
@@ -0,0 +1,272 @@
+package registryinstance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
+)
+
+const (
+	basePath = "/apis/registry/v2"
+
+	RuleTypeValidity      = "VALIDITY"
+	RuleTypeCompatibility = "COMPATIBILITY"
+
+	RoleReadOnly  = "READ_ONLY"
+	RoleDeveloper = "DEVELOPER"
+	RoleAdmin     = "ADMIN"
+)
+
+var (
+	RuleTypes = []string{RuleTypeValidity, RuleTypeCompatibility}
+
+	// the configurations of a rule by the type of the rule
+	RuleConfigs = map[string][]string{
+		RuleTypeValidity:      {"NONE", "SYNTAX_ONLY", "FULL"},
+		RuleTypeCompatibility: {"NONE", "BACKWARD", "BACKWARD_TRANSITIVE", "FORWARD", "FORWARD_TRANSITIVE", "FULL", "FULL_TRANSITIVE"},
+	}
+
+	Roles = []string{RoleReadOnly, RoleDeveloper, RoleAdmin}
+)
+
+// APIClient calls the core api of a Service Registry instance. It stands in for
+// the registryinstance module of the app services sdk, which the provider does
+// not depend on yet
+type APIClient struct {
+	client *restapi.Client
+}
+
+// ArtifactMetaData describes the latest version of an artifact
+type ArtifactMetaData struct {
+	GroupID    string `json:"groupId"`
+	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Type       string `json:"type"`
+	Version    string `json:"version"`
+	GlobalID   int64  `json:"globalId"`
+	ContentID  int64  `json:"contentId"`
+	State      string `json:"state,omitempty"`
+	CreatedOn  string `json:"createdOn,omitempty"`
+	ModifiedOn string `json:"modifiedOn,omitempty"`
+}
+
+// VersionMetaData describes a version of an artifact
+type VersionMetaData struct {
+	GroupID   string `json:"groupId"`
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Version   string `json:"version"`
+	GlobalID  int64  `json:"globalId"`
+	ContentID int64  `json:"contentId"`
+	State     string `json:"state,omitempty"`
+	CreatedOn string `json:"createdOn,omitempty"`
+}
+
+// Rule is a validity or compatibility rule applied to new artifact content
+type Rule struct {
+	Type   string `json:"type"`
+	Config string `json:"config"`
+}
+
+// RoleMapping grants a role in the registry to a user or service account
+type RoleMapping struct {
+	PrincipalID   string `json:"principalId"`
+	Role          string `json:"role"`
+	PrincipalName string `json:"principalName,omitempty"`
+}
+
+// API is the core api of a Service Registry instance used by the provider. The
+// factory returns it rather than the APIClient so that it can be replaced in tests
+type API interface {
+	CreateArtifact(ctx context.Context, groupID string, artifactID string, artifactType string, content string) (ArtifactMetaData, *http.Response, error)
+	CreateArtifactVersion(ctx context.Context, groupID string, artifactID string, artifactType string, content string) (VersionMetaData, *http.Response, error)
+	GetArtifactMetaData(ctx context.Context, groupID string, artifactID string) (ArtifactMetaData, *http.Response, error)
+	GetLatestArtifact(ctx context.Context, groupID string, artifactID string) (string, *http.Response, error)
+	DeleteArtifact(ctx context.Context, groupID string, artifactID string) (*http.Response, error)
+	ListArtifactRules(ctx context.Context, groupID string, artifactID string) ([]string, *http.Response, error)
+	GetArtifactRule(ctx context.Context, groupID string, artifactID string, ruleType string) (Rule, *http.Response, error)
+	CreateArtifactRule(ctx context.Context, groupID string, artifactID string, rule Rule) (*http.Response, error)
+	UpdateArtifactRule(ctx context.Context, groupID string, artifactID string, rule Rule) (Rule, *http.Response, error)
+	DeleteArtifactRule(ctx context.Context, groupID string, artifactID string, ruleType string) (*http.Response, error)
+	GetRule(ctx context.Context, ruleType string) (Rule, *http.Response, error)
+	CreateRule(ctx context.Context, rule Rule) (*http.Response, error)
+	UpdateRule(ctx context.Context, rule Rule) (Rule, *http.Response, error)
+	DeleteRule(ctx context.Context, ruleType string) (*http.Response, error)
+	CreateRoleMapping(ctx context.Context, roleMapping RoleMapping) (*http.Response, error)
+	GetRoleMapping(ctx context.Context, principalID string) (RoleMapping, *http.Response, error)
+	UpdateRoleMapping(ctx context.Context, principalID string, role string) (*http.Response, error)
+	DeleteRoleMapping(ctx context.Context, principalID string) (*http.Response, error)
+}
+
+var _ API = &APIClient{}
+
+// NewAPIClient returns a client for the api of the Service Registry instance
+// with the given registry url, e.g. https://example.serviceregistry.rhcloud.com/t/<id>
+func NewAPIClient(httpClient *http.Client, registryURL string) *APIClient {
+	return &APIClient{
+		client: restapi.NewClient(httpClient, registryURL),
+	}
+}
+
+func artifactPath(groupID string, artifactID string) string {
+	return fmt.Sprintf("%s/groups/%s/artifacts/%s", basePath, url.PathEscape(groupID), url.PathEscape(artifactID))
+}
+
+// artifactContent returns the request body for artifact content. The type of the
+// artifact is sent along with the content so the registry does not have to guess it
+func artifactContent(artifactType string, content string, header http.Header) restapi.Content {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("X-Registry-ArtifactType", artifactType)
+
+	contentType := "application/json"
+	if artifactType == "PROTOBUF" {
+		contentType = "application/x-protobuf"
+	}
+
+	return restapi.Content{
+		ContentType: contentType,
+		Data:        content,
+		Header:      header,
+	}
+}
+
+// CreateArtifact creates an artifact in the group with the content as its first version.
+// It fails if an artifact with the same id already exists in the group
+func (c *APIClient) CreateArtifact(ctx context.Context, groupID string, artifactID string, artifactType string, content string) (ArtifactMetaData, *http.Response, error) {
+	header := http.Header{}
+	header.Set("X-Registry-ArtifactId", artifactID)
+
+	query := url.Values{}
+	query.Set("ifExists", "FAIL")
+
+	var metaData ArtifactMetaData
+	resp, err := c.client.Do(ctx, http.MethodPost, fmt.Sprintf("%s/groups/%s/artifacts", basePath, url.PathEscape(groupID)), query, artifactContent(artifactType, content, header), &metaData)
+
+	return metaData, resp, err
+}
+
+// CreateArtifactVersion adds the content as the new latest version of the artifact,
+// after checking it against the rules of the artifact
+func (c *APIClient) CreateArtifactVersion(ctx context.Context, groupID string, artifactID string, artifactType string, content string) (VersionMetaData, *http.Response, error) {
+	var metaData VersionMetaData
+	resp, err := c.client.Do(ctx, http.MethodPost, artifactPath(groupID, artifactID)+"/versions", nil, artifactContent(artifactType, content, nil), &metaData)
+
+	return metaData, resp, err
+}
+
+// GetArtifactMetaData returns the metadata of the latest version of the artifact
+func (c *APIClient) GetArtifactMetaData(ctx context.Context, groupID string, artifactID string) (ArtifactMetaData, *http.Response, error) {
+	var metaData ArtifactMetaData
+	resp, err := c.client.Get(ctx, artifactPath(groupID, artifactID)+"/meta", nil, &metaData)
+
+	return metaData, resp, err
+}
+
+// GetLatestArtifact returns the content of the latest version of the artifact
+func (c *APIClient) GetLatestArtifact(ctx context.Context, groupID string, artifactID string) (string, *http.Response, error) {
+	var content string
+	resp, err := c.client.Get(ctx, artifactPath(groupID, artifactID), nil, &content)
+
+	return content, resp, err
+}
+
+// DeleteArtifact deletes the artifact along with all of its versions
+func (c *APIClient) DeleteArtifact(ctx context.Context, groupID string, artifactID string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, artifactPath(groupID, artifactID), nil, nil, nil)
+}
+
+// ListArtifactRules returns the types of the rules configured for the artifact
+func (c *APIClient) ListArtifactRules(ctx context.Context, groupID string, artifactID string) ([]string, *http.Response, error) {
+	ruleTypes := make([]string, 0)
+	resp, err := c.client.Get(ctx, artifactPath(groupID, artifactID)+"/rules", nil, &ruleTypes)
+
+	return ruleTypes, resp, err
+}
+
+// GetArtifactRule returns the configuration of a rule of the artifact
+func (c *APIClient) GetArtifactRule(ctx context.Context, groupID string, artifactID string, ruleType string) (Rule, *http.Response, error) {
+	var rule Rule
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/rules/%s", artifactPath(groupID, artifactID), ruleType), nil, &rule)
+
+	return rule, resp, err
+}
+
+// CreateArtifactRule adds a rule to the artifact, failing if it already has a rule of the same type
+func (c *APIClient) CreateArtifactRule(ctx context.Context, groupID string, artifactID string, rule Rule) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodPost, artifactPath(groupID, artifactID)+"/rules", nil, rule, nil)
+}
+
+// UpdateArtifactRule changes the configuration of a rule of the artifact
+func (c *APIClient) UpdateArtifactRule(ctx context.Context, groupID string, artifactID string, rule Rule) (Rule, *http.Response, error) {
+	var updated Rule
+	resp, err := c.client.Do(ctx, http.MethodPut, fmt.Sprintf("%s/rules/%s", artifactPath(groupID, artifactID), rule.Type), nil, rule, &updated)
+
+	return updated, resp, err
+}
+
+// DeleteArtifactRule removes a rule from the artifact
+func (c *APIClient) DeleteArtifactRule(ctx context.Context, groupID string, artifactID string, ruleType string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, fmt.Sprintf("%s/rules/%s", artifactPath(groupID, artifactID), ruleType), nil, nil, nil)
+}
+
+// rulesPath is the path of the global rules of the registry. Version 2 of the
+// registry api has no rules of a group, only global rules and rules of an artifact
+const rulesPath = basePath + "/admin/rules"
+
+// GetRule returns the configuration of a global rule of the registry
+func (c *APIClient) GetRule(ctx context.Context, ruleType string) (Rule, *http.Response, error) {
+	var rule Rule
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/%s", rulesPath, ruleType), nil, &rule)
+
+	return rule, resp, err
+}
+
+// CreateRule adds a global rule to the registry, failing if it has a rule of the same type already
+func (c *APIClient) CreateRule(ctx context.Context, rule Rule) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodPost, rulesPath, nil, rule, nil)
+}
+
+// UpdateRule changes the configuration of a global rule of the registry
+func (c *APIClient) UpdateRule(ctx context.Context, rule Rule) (Rule, *http.Response, error) {
+	var updated Rule
+	resp, err := c.client.Do(ctx, http.MethodPut, fmt.Sprintf("%s/%s", rulesPath, rule.Type), nil, rule, &updated)
+
+	return updated, resp, err
+}
+
+// DeleteRule removes a global rule from the registry
+func (c *APIClient) DeleteRule(ctx context.Context, ruleType string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", rulesPath, ruleType), nil, nil, nil)
+}
+
+func roleMappingPath(principalID string) string {
+	return fmt.Sprintf("%s/admin/roleMappings/%s", basePath, url.PathEscape(principalID))
+}
+
+// CreateRoleMapping grants a role to a principal, failing if the principal has a role already
+func (c *APIClient) CreateRoleMapping(ctx context.Context, roleMapping RoleMapping) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodPost, basePath+"/admin/roleMappings", nil, roleMapping, nil)
+}
+
+// GetRoleMapping returns the role granted to a principal
+func (c *APIClient) GetRoleMapping(ctx context.Context, principalID string) (RoleMapping, *http.Response, error) {
+	var roleMapping RoleMapping
+	resp, err := c.client.Get(ctx, roleMappingPath(principalID), nil, &roleMapping)
+
+	return roleMapping, resp, err
+}
+
+// UpdateRoleMapping changes the role granted to a principal
+func (c *APIClient) UpdateRoleMapping(ctx context.Context, principalID string, role string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodPut, roleMappingPath(principalID), nil, map[string]string{"role": role}, nil)
+}
+
+// DeleteRoleMapping revokes the role granted to a principal
+func (c *APIClient) DeleteRoleMapping(ctx context.Context, principalID string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, roleMappingPath(principalID), nil, nil, nil)
+}
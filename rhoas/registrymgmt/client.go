@@ -0,0 +1,128 @@
+package registrymgmt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
+)
+
+const (
+	basePath = "/api/serviceregistry_mgmt/v1/registries"
+
+	// the number of registries requested for each page of the list api
+	registriesPageSize = 100
+)
+
+// APIClient calls the Red Hat OpenShift Service Registry management api. It
+// stands in for the registrymgmt module of the app services sdk, which the
+// provider does not depend on yet
+type APIClient struct {
+	client *restapi.Client
+}
+
+// Registry is a Service Registry instance
+type Registry struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	Description          string `json:"description,omitempty"`
+	Status               string `json:"status"`
+	RegistryURL          string `json:"registryUrl,omitempty"`
+	BrowserURL           string `json:"browserUrl,omitempty"`
+	Owner                string `json:"owner,omitempty"`
+	InstanceType         string `json:"instance_type,omitempty"`
+	RegistryDeploymentID int    `json:"registryDeploymentId,omitempty"`
+	CreatedAt            string `json:"created_at,omitempty"`
+	UpdatedAt            string `json:"updated_at,omitempty"`
+}
+
+// RegistryCreate is the request to create a Service Registry instance
+type RegistryCreate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// RegistryList is a page of Service Registry instances
+type RegistryList struct {
+	Page  int        `json:"page"`
+	Size  int        `json:"size"`
+	Total int        `json:"total"`
+	Items []Registry `json:"items"`
+}
+
+// API is the Service Registry management api used by the provider. The factory
+// returns it rather than the APIClient so that it can be replaced in tests
+type API interface {
+	CreateRegistry(ctx context.Context, registry RegistryCreate) (Registry, *http.Response, error)
+	GetRegistry(ctx context.Context, id string) (Registry, *http.Response, error)
+	DeleteRegistry(ctx context.Context, id string) (*http.Response, error)
+	GetRegistries(ctx context.Context, search string, page int) (RegistryList, *http.Response, error)
+	ListRegistries(ctx context.Context, search string) ([]Registry, *http.Response, error)
+}
+
+var _ API = &APIClient{}
+
+func NewAPIClient(httpClient *http.Client, baseURL string) *APIClient {
+	return &APIClient{
+		client: restapi.NewClient(httpClient, baseURL),
+	}
+}
+
+// CreateRegistry requests a new Service Registry instance, which is created asynchronously
+func (c *APIClient) CreateRegistry(ctx context.Context, registry RegistryCreate) (Registry, *http.Response, error) {
+	var created Registry
+	resp, err := c.client.Do(ctx, http.MethodPost, basePath, nil, registry, &created)
+
+	return created, resp, err
+}
+
+// GetRegistry returns the Service Registry instance with the given id
+func (c *APIClient) GetRegistry(ctx context.Context, id string) (Registry, *http.Response, error) {
+	var registry Registry
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/%s", basePath, url.PathEscape(id)), nil, &registry)
+
+	return registry, resp, err
+}
+
+// DeleteRegistry requests the deletion of the Service Registry instance with
+// the given id, which is deleted asynchronously
+func (c *APIClient) DeleteRegistry(ctx context.Context, id string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", basePath, url.PathEscape(id)), nil, nil, nil)
+}
+
+// GetRegistries returns a page of the Service Registry instances matching the
+// search query, e.g. "name = my-registry"
+func (c *APIClient) GetRegistries(ctx context.Context, search string, page int) (RegistryList, *http.Response, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(registriesPageSize))
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	var list RegistryList
+	resp, err := c.client.Get(ctx, basePath, query, &list)
+
+	return list, resp, err
+}
+
+// ListRegistries returns all of the Service Registry instances matching the search query
+func (c *APIClient) ListRegistries(ctx context.Context, search string) ([]Registry, *http.Response, error) {
+	registries := make([]Registry, 0)
+
+	for page := 1; ; page++ {
+		list, resp, err := c.GetRegistries(ctx, search, page)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		registries = append(registries, list.Items...)
+
+		if len(list.Items) < registriesPageSize || len(registries) >= list.Total {
+			return registries, resp, nil
+		}
+	}
+}
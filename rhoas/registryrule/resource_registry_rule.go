@@ -0,0 +1,238 @@
+package registryrule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RegistryIDField = "registry_id"
+	TypeField       = "type"
+	ConfigField     = "config"
+
+	// separates the registry id and the type of the rule in the id of the resource
+	idSeparator = "/"
+)
+
+func ResourceRegistryRule(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_registry_rule` manages a global validity or compatibility rule of a Service Registry instance in Red Hat OpenShift Service Registry, which applies to all of the artifacts without a rule of their own. The rules of a single artifact are managed by `rhoas_registry_artifact`. Rules of a group of artifacts are not supported, as the v2 API of Service Registry only has global and artifact rules.",
+		CreateContext: registryRuleCreate,
+		ReadContext:   registryRuleRead,
+		UpdateContext: registryRuleUpdate,
+		DeleteContext: registryRuleDelete,
+		CustomizeDiff: registryRuleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: registryRuleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			RegistryIDField: {
+				Description: localizer.MustLocalize("registryrule.resource.field.description.registryID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			TypeField: {
+				Description:  localizer.MustLocalize("registryrule.resource.field.description.type"),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(registryinstance.RuleTypes, false),
+			},
+			ConfigField: {
+				Description: localizer.MustLocalize("registryrule.resource.field.description.config"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
+}
+
+// getRuleID returns the registry id and type of the rule of the resource
+func getRuleID(factory rhoasAPI.Factory, d *schema.ResourceData) (string, string, error) {
+	ids := make([]string, 2)
+
+	for i, field := range []string{RegistryIDField, TypeField} {
+		id, ok := d.Get(field).(string)
+		if !ok {
+			return "", "", factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field))
+		}
+
+		ids[i] = id
+	}
+
+	return ids[0], ids[1], nil
+}
+
+func registryRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ruleType, err := getRuleID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, ok := d.Get(ConfigField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ConfigField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.CreateRule(ctx, registryinstance.Rule{Type: ruleType, Config: config})
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(strings.Join([]string{registryID, ruleType}, idSeparator))
+
+	return registryRuleRead(ctx, d, m)
+}
+
+func registryRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ruleType, err := getRuleID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, resp, err := registryAPI.GetRule(ctx, ruleType)
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the rule was removed outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = d.Set(ConfigField, rule.Config); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func registryRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ruleType, err := getRuleID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, ok := d.Get(ConfigField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ConfigField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, resp, err := registryAPI.UpdateRule(ctx, registryinstance.Rule{Type: ruleType, Config: config})
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	return registryRuleRead(ctx, d, m)
+}
+
+func registryRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ruleType, err := getRuleID(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.DeleteRule(ctx, ruleType)
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// registryRuleCustomizeDiff checks that the configuration is valid for the type
+// of the rule, which a validation of the configuration alone cannot know
+func registryRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	ruleType, _ := d.Get(TypeField).(string)
+	config, _ := d.Get(ConfigField).(string)
+
+	configs, ok := registryinstance.RuleConfigs[ruleType]
+	if !ok || config == "" {
+		// the type is validated by its schema and the configuration may be unknown
+		return nil
+	}
+
+	for _, c := range configs {
+		if c == config {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expected %s of a %s rule to be one of %q, got %s", ConfigField, ruleType, configs, config)
+}
+
+// registryRuleImport imports a rule from an id of the form <registry_id>/<type>
+func registryRuleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	registryID, ruleType, found := strings.Cut(d.Id(), idSeparator)
+	if !found || registryID == "" || ruleType == "" || strings.Contains(ruleType, idSeparator) {
+		return nil, fmt.Errorf("unexpected id %q, expected <registry_id>%s<type>", d.Id(), idSeparator)
+	}
+
+	for field, value := range map[string]string{RegistryIDField: registryID, TypeField: ruleType} {
+		if err := d.Set(field, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,173 @@
+package registryrule
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRegistryFactory returns a factory whose registry is a local fake, along
+// with its global rules by type. Any other request fails the test
+func testRegistryFactory(t *testing.T) (rhoasAPI.Factory, map[string]string) {
+	rules := map[string]string{}
+
+	factory := fakeapi.RegistryFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		var rule registryinstance.Rule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+
+		if r.URL.Path == "/admin/rules" && r.Method == http.MethodPost {
+			if _, ok := rules[rule.Type]; ok {
+				fakeapi.WriteJSON(w, http.StatusConflict, map[string]interface{}{"error_code": 409, "name": "RuleAlreadyExistsException", "message": "A rule named '" + rule.Type + "' already exists."})
+				return
+			}
+			rules[rule.Type] = rule.Config
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		ruleType := strings.TrimPrefix(r.URL.Path, "/admin/rules/")
+		if ruleType == r.URL.Path || strings.Contains(ruleType, "/") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+
+		config, ok := rules[ruleType]
+		if !ok {
+			fakeapi.WriteJSON(w, http.StatusNotFound, map[string]interface{}{"error_code": 404, "name": "RuleNotFoundException", "message": "No rule named '" + ruleType + "' was found."})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			rules[ruleType] = rule.Config
+			fakeapi.WriteJSON(w, http.StatusOK, rule)
+		case http.MethodDelete:
+			delete(rules, ruleType)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			fakeapi.WriteJSON(w, http.StatusOK, registryinstance.Rule{Type: ruleType, Config: config})
+		}
+	})
+
+	return factory, rules
+}
+
+func TestResourceRegistryRuleLifecycle(t *testing.T) {
+	factory, rules := testRegistryFactory(t)
+	r := ResourceRegistryRule(factory.Localizer())
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		RegistryIDField: fakeapi.RegistryID,
+		TypeField:       registryinstance.RuleTypeCompatibility,
+		ConfigField:     "BACKWARD",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "test-registry/COMPATIBILITY", d.Id())
+	assert.Equal(t, map[string]string{registryinstance.RuleTypeCompatibility: "BACKWARD"}, rules)
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, rules)
+}
+
+func TestResourceRegistryRuleUpdate(t *testing.T) {
+	factory, rules := testRegistryFactory(t)
+	rules[registryinstance.RuleTypeValidity] = "SYNTAX_ONLY"
+
+	r := ResourceRegistryRule(factory.Localizer())
+	state := &terraform.InstanceState{
+		ID: "test-registry/VALIDITY",
+		Attributes: map[string]string{
+			RegistryIDField: "test-registry",
+			TypeField:       registryinstance.RuleTypeValidity,
+			ConfigField:     "SYNTAX_ONLY",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		RegistryIDField: "test-registry",
+		TypeField:       registryinstance.RuleTypeValidity,
+		ConfigField:     "FULL",
+	}), nil)
+	assert.NoError(t, err)
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+
+	diags := r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "FULL", rules[registryinstance.RuleTypeValidity])
+	assert.Equal(t, "FULL", d.Get(ConfigField))
+}
+
+func TestResourceRegistryRuleConfigValidation(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceRegistryRule(localizer)
+
+	tests := []struct {
+		name     string
+		ruleType string
+		config   string
+		wantErr  bool
+	}{
+		{name: "validity", ruleType: registryinstance.RuleTypeValidity, config: "SYNTAX_ONLY"},
+		{name: "compatibility", ruleType: registryinstance.RuleTypeCompatibility, config: "FORWARD_TRANSITIVE"},
+		{name: "compatibility config of a validity rule", ruleType: registryinstance.RuleTypeValidity, config: "BACKWARD", wantErr: true},
+		{name: "validity config of a compatibility rule", ruleType: registryinstance.RuleTypeCompatibility, config: "SYNTAX_ONLY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				RegistryIDField: "test-registry",
+				TypeField:       tt.ruleType, // nolint:scopelint
+				ConfigField:     tt.config,   // nolint:scopelint
+			}), nil)
+			assert.Equal(t, tt.wantErr, err != nil, err) // nolint:scopelint
+		})
+	}
+}
+
+func TestRegistryRuleImport(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceRegistryRule(localizer)
+
+	tests := []struct {
+		name     string
+		id       string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "global rule", id: "test-registry/VALIDITY", wantType: "VALIDITY"},
+		{name: "group rule", id: "test-registry/orders/COMPATIBILITY", wantErr: true},
+		{name: "missing type", id: "test-registry", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := r.TestResourceData()
+			d.SetId(tt.id) // nolint:scopelint
+
+			_, err := registryRuleImport(context.Background(), d, nil)
+			assert.Equal(t, tt.wantErr, err != nil, err) // nolint:scopelint
+			if !tt.wantErr {                             // nolint:scopelint
+				assert.Equal(t, "test-registry", d.Get(RegistryIDField))
+				assert.Equal(t, tt.wantType, d.Get(TypeField)) // nolint:scopelint
+			}
+		})
+	}
+}
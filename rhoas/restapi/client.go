@@ -0,0 +1,166 @@
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client sends json requests to the apis of the provider for which it does not
+// depend on a client of the app services sdk. The http client is expected to
+// authenticate the requests
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Error is the error returned by an api. The management apis return the code
+// and the reason of an error, the instance apis its message and detail and,
+// for content rejected by the rules of a registry, the causes
+type Error struct {
+	StatusCode int     `json:"-"`
+	Code       string  `json:"code"`
+	Reason     string  `json:"reason"`
+	Name       string  `json:"name"`
+	Message    string  `json:"message"`
+	Detail     string  `json:"detail"`
+	Causes     []Cause `json:"causes"`
+	body       []byte
+}
+
+// Cause is a reason for content to be rejected, e.g. a rule it violates
+type Cause struct {
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Causes) > 0 {
+		causes := make([]string, len(e.Causes))
+		for i, cause := range e.Causes {
+			causes[i] = cause.Description
+			if cause.Context != "" {
+				causes[i] = fmt.Sprintf("%s (%s)", cause.Description, cause.Context)
+			}
+		}
+
+		return fmt.Sprintf("%d %s: %s: %s", e.StatusCode, e.Name, e.Message, strings.Join(causes, "; "))
+	}
+
+	switch {
+	case e.Reason != "":
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Reason)
+	case e.Message != "" && e.Detail != "" && e.Detail != e.Message:
+		return fmt.Sprintf("%d %s: %s: %s", e.StatusCode, e.Name, e.Message, e.Detail)
+	case e.Message != "":
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Name, e.Message)
+	}
+
+	return fmt.Sprintf("%d %s", e.StatusCode, strings.TrimSpace(string(e.body)))
+}
+
+// IsNotFound returns whether the error is an api error for a missing resource
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func NewClient(httpClient *http.Client, baseURL string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Get decodes the json response to a GET request of the path into v
+func (c *Client) Get(ctx context.Context, path string, query url.Values, v interface{}) (*http.Response, error) {
+	return c.Do(ctx, http.MethodGet, path, query, nil, v)
+}
+
+// Do sends a request with the json encoded body, or the content of the body when
+// it is a Content, and decodes the json response into v if it is not nil. When
+// v is a *string the response is stored as is, whatever its content type
+func (c *Client) Do(ctx context.Context, method string, path string, query url.Values, body interface{}, v interface{}) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	var header http.Header
+	contentType := ""
+	switch b := body.(type) {
+	case nil:
+	case Content:
+		reader = strings.NewReader(b.Data)
+		contentType = b.ContentType
+		header = b.Header
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	raw, isRaw := v.(*string)
+	if isRaw {
+		req.Header.Set("Accept", "*/*")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return resp, err
+	}
+	// keep the body readable for the error handling of the caller
+	resp.Body = io.NopCloser(bytes.NewBuffer(data))
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &Error{StatusCode: resp.StatusCode, body: data}
+		_ = json.Unmarshal(data, apiErr)
+		return resp, apiErr
+	}
+
+	if isRaw {
+		*raw = string(data)
+		return resp, nil
+	}
+
+	if v == nil || len(data) == 0 {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(data, v)
+}
+
+// Content is a request body which is sent as is instead of being json encoded,
+// along with the headers describing it
+type Content struct {
+	ContentType string
+	Data        string
+	Header      http.Header
+}
@@ -0,0 +1,243 @@
+package rolemapping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RegistryIDField    = "registry_id"
+	PrincipalIDField   = "principal_id"
+	PrincipalNameField = "principal_name"
+	RoleField          = "role"
+
+	// separates the registry id from the principal id in the id of the resource
+	idSeparator = "/"
+)
+
+func ResourceRegistryRoleMapping(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_registry_role_mapping` grants a role in a Service Registry instance in Red Hat OpenShift Service Registry to a user or service account.",
+		CreateContext: registryRoleMappingCreate,
+		ReadContext:   registryRoleMappingRead,
+		UpdateContext: registryRoleMappingUpdate,
+		DeleteContext: registryRoleMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: registryRoleMappingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			RegistryIDField: {
+				Description: localizer.MustLocalize("rolemapping.resource.field.description.registryID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			PrincipalIDField: {
+				Description: localizer.MustLocalize("rolemapping.resource.field.description.principalID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			PrincipalNameField: {
+				Description: localizer.MustLocalize("rolemapping.resource.field.description.principalName"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			RoleField: {
+				Description:  localizer.MustLocalize("rolemapping.resource.field.description.role"),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(registryinstance.Roles, false),
+			},
+		},
+	}
+}
+
+func registryRoleMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ok := d.Get(RegistryIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RegistryIDField)))
+	}
+
+	principalID, ok := d.Get(PrincipalIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalIDField)))
+	}
+
+	principalName, ok := d.Get(PrincipalNameField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalNameField)))
+	}
+
+	role, ok := d.Get(RoleField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RoleField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.CreateRoleMapping(ctx, registryinstance.RoleMapping{
+		PrincipalID:   principalID,
+		PrincipalName: principalName,
+		Role:          role,
+	})
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(registryID + idSeparator + principalID)
+
+	return registryRoleMappingRead(ctx, d, m)
+}
+
+func registryRoleMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ok := d.Get(RegistryIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RegistryIDField)))
+	}
+
+	principalID, ok := d.Get(PrincipalIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalIDField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleMapping, resp, err := registryAPI.GetRoleMapping(ctx, principalID)
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the role was revoked outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = d.Set(RoleField, roleMapping.Role); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set(PrincipalNameField, roleMapping.PrincipalName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func registryRoleMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ok := d.Get(RegistryIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RegistryIDField)))
+	}
+
+	principalID, ok := d.Get(PrincipalIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalIDField)))
+	}
+
+	role, ok := d.Get(RoleField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RoleField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.UpdateRoleMapping(ctx, principalID, role)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	return registryRoleMappingRead(ctx, d, m)
+}
+
+func registryRoleMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	registryID, ok := d.Get(RegistryIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RegistryIDField)))
+	}
+
+	principalID, ok := d.Get(PrincipalIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PrincipalIDField)))
+	}
+
+	registryAPI, _, err := factory.RegistryInstance(&ctx, registryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := registryAPI.DeleteRoleMapping(ctx, principalID)
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// registryRoleMappingImport imports a role mapping from an id of the form <registry_id>/<principal_id>
+func registryRoleMappingImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	registryID, principalID, found := strings.Cut(d.Id(), idSeparator)
+	if !found || registryID == "" || principalID == "" {
+		return nil, fmt.Errorf("unexpected id %q, expected <registry_id>%s<principal_id>", d.Id(), idSeparator)
+	}
+
+	if err := d.Set(RegistryIDField, registryID); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(PrincipalIDField, principalID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,95 @@
+package rolemapping
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/registryinstance"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRegistryFactory returns a factory whose registry is a local fake, along
+// with its role mappings by principal id. Any other request fails the test
+func testRegistryFactory(t *testing.T) (rhoasAPI.Factory, map[string]registryinstance.RoleMapping) {
+	roleMappings := map[string]registryinstance.RoleMapping{}
+
+	factory := fakeapi.RegistryFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		var roleMapping registryinstance.RoleMapping
+		_ = json.NewDecoder(r.Body).Decode(&roleMapping)
+
+		if r.URL.Path == "/admin/roleMappings" && r.Method == http.MethodPost {
+			roleMappings[roleMapping.PrincipalID] = roleMapping
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		principalID := strings.TrimPrefix(r.URL.Path, "/admin/roleMappings/")
+		if principalID == r.URL.Path || strings.Contains(principalID, "/") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+
+		existing, ok := roleMappings[principalID]
+		if !ok {
+			fakeapi.WriteJSON(w, http.StatusNotFound, map[string]interface{}{"error_code": 404, "name": "RoleMappingNotFoundException", "message": "No mapping for principal " + principalID})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			existing.Role = roleMapping.Role
+			roleMappings[principalID] = existing
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(roleMappings, principalID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			fakeapi.WriteJSON(w, http.StatusOK, existing)
+		}
+	})
+
+	return factory, roleMappings
+}
+
+func TestResourceRegistryRoleMappingLifecycle(t *testing.T) {
+	factory, roleMappings := testRegistryFactory(t)
+
+	r := ResourceRegistryRoleMapping(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		RegistryIDField:    "test-registry",
+		PrincipalIDField:   "srvc-acct-1234",
+		PrincipalNameField: "orders-producer",
+		RoleField:          registryinstance.RoleReadOnly,
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "test-registry/srvc-acct-1234", d.Id())
+	assert.Equal(t, registryinstance.RoleMapping{PrincipalID: "srvc-acct-1234", PrincipalName: "orders-producer", Role: registryinstance.RoleReadOnly}, roleMappings["srvc-acct-1234"])
+
+	roleMappings["srvc-acct-1234"] = registryinstance.RoleMapping{PrincipalID: "srvc-acct-1234", PrincipalName: "orders-producer", Role: registryinstance.RoleAdmin}
+	diags = r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, registryinstance.RoleAdmin, d.Get(RoleField), "expected the role changed outside of terraform to be read")
+
+	assert.NoError(t, d.Set(RoleField, registryinstance.RoleDeveloper))
+	diags = r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, registryinstance.RoleDeveloper, roleMappings["srvc-acct-1234"].Role)
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, roleMappings)
+
+	diags = r.ReadContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id(), "expected the revoked role to be removed from the state")
+}
@@ -0,0 +1,166 @@
+package serviceaccount
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	ServiceAccountIDField = "service_account_id"
+	RevokeOnCloseField    = "revoke_on_close"
+
+	// revokePrivateKey is the key of the private data holding the id of the
+	// service account whose secret is revoked when closed
+	revokePrivateKey = "revoke_service_account_id"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &serviceAccountCredentialsEphemeral{}
+	_ ephemeral.EphemeralResourceWithClose     = &serviceAccountCredentialsEphemeral{}
+)
+
+// serviceAccountCredentialsEphemeral is the rhoas_service_account_credentials
+// ephemeral resource, resetting the secret of a service account when opened.
+// The secret is handed to the configuration without being stored in the state
+// or the plan
+type serviceAccountCredentialsEphemeral struct {
+	localizer localize.Localizer
+	factory   rhoasAPI.Factory
+}
+
+// serviceAccountCredentialsModel maps the attributes of the
+// rhoas_service_account_credentials ephemeral resource
+type serviceAccountCredentialsModel struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	RevokeOnClose    types.Bool   `tfsdk:"revoke_on_close"`
+	ClientID         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+}
+
+func NewEphemeralServiceAccountCredentials(localizer localize.Localizer) ephemeral.EphemeralResource {
+	return &serviceAccountCredentialsEphemeral{
+		localizer: localizer,
+	}
+}
+
+func (e *serviceAccountCredentialsEphemeral) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_credentials"
+}
+
+func (e *serviceAccountCredentialsEphemeral) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`rhoas_service_account_credentials` resets the secret of a service account in Red Hat OpenShift Application Services without storing it in the state or the plan. Terraform opens the ephemeral resource on every plan and apply, so each of them resets the secret and the previous secret is no longer valid. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			ServiceAccountIDField: schema.StringAttribute{
+				Description: e.localizer.MustLocalize("serviceaccount.ephemeral.field.description.serviceAccountID"),
+				Required:    true,
+			},
+			RevokeOnCloseField: schema.BoolAttribute{
+				Description: e.localizer.MustLocalize("serviceaccount.ephemeral.field.description.revokeOnClose"),
+				Optional:    true,
+				Computed:    true,
+			},
+			ClientIDField: schema.StringAttribute{
+				Description: e.localizer.MustLocalize("serviceaccount.resource.field.description.clientID"),
+				Computed:    true,
+			},
+			ClientSecret: schema.StringAttribute{
+				Description: e.localizer.MustLocalize("serviceaccount.ephemeral.field.description.clientSecret"),
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (e *serviceAccountCredentialsEphemeral) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// the provider data is not set when the ephemeral resource is validated
+	if req.ProviderData == nil {
+		return
+	}
+
+	factory, ok := req.ProviderData.(rhoasAPI.Factory)
+	if !ok {
+		resp.Diagnostics.AddError(e.localizer.MustLocalize("common.errors.configure"), fmt.Sprintf("unable to cast %v to rhoasAPI.Factory", req.ProviderData))
+		return
+	}
+
+	e.factory = factory
+}
+
+func (e *serviceAccountCredentialsEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model serviceAccountCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := model.ServiceAccountID.ValueString()
+
+	serviceAccount, err := ResetServiceAccountSecret(ctx, e.factory, id)
+	if err != nil {
+		resp.Diagnostics.AddError(e.localizer.MustLocalize("serviceaccount.ephemeral.errors.reset", localize.NewEntry("ID", id)), err.Error())
+		return
+	}
+
+	// ephemeral attributes have no default, the secret is only revoked when
+	// asked to
+	if model.RevokeOnClose.IsNull() || model.RevokeOnClose.IsUnknown() {
+		model.RevokeOnClose = types.BoolValue(false)
+	}
+	model.ClientID = types.StringValue(serviceAccount.GetClientId())
+	model.ClientSecret = types.StringValue(serviceAccount.GetSecret())
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+
+	if model.RevokeOnClose.ValueBool() {
+		value, err := json.Marshal(id)
+		if err != nil {
+			resp.Diagnostics.AddError(e.localizer.MustLocalize("serviceaccount.ephemeral.errors.reset", localize.NewEntry("ID", id)), err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, revokePrivateKey, value)...)
+	}
+}
+
+// Close revokes the secret handed out when opened by resetting it again, the
+// new secret being discarded
+func (e *serviceAccountCredentialsEphemeral) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, revokePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(value) == 0 {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(value, &id); err != nil {
+		resp.Diagnostics.AddError(e.localizer.MustLocalize("serviceaccount.ephemeral.errors.revoke", localize.NewEntry("ID", id)), err.Error())
+		return
+	}
+
+	if _, err := ResetServiceAccountSecret(ctx, e.factory, id); err != nil {
+		resp.Diagnostics.AddError(e.localizer.MustLocalize("serviceaccount.ephemeral.errors.revoke", localize.NewEntry("ID", id)), err.Error())
+	}
+}
+
+// ResetServiceAccountSecret resets the secret of the service account with the
+// given id, the previous secret is no longer valid and the new one is only
+// returned by this call
+func ResetServiceAccountSecret(ctx context.Context, factory rhoasAPI.Factory, id string) (*serviceAccounts.ServiceAccountData, error) {
+	serviceAccount, resp, err := factory.ServiceAccountMgmt().ResetServiceAccountSecret(ctx, id).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return &serviceAccount, nil
+}
@@ -0,0 +1,198 @@
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+const testServiceAccountID = "test-sa"
+
+// testCredentialsProvider only serves the rhoas_service_account_credentials
+// ephemeral resource, so that it is opened and closed by the plugin framework,
+// which is the only one able to create its private data
+type testCredentialsProvider struct {
+	factory rhoasAPI.Factory
+}
+
+func (p *testCredentialsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "rhoas"
+}
+
+func (p *testCredentialsProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p *testCredentialsProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.EphemeralResourceData = p.factory
+}
+
+func (p *testCredentialsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *testCredentialsProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *testCredentialsProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return NewEphemeralServiceAccountCredentials(p.factory.Localizer())
+		},
+	}
+}
+
+// testCredentialsServer returns a configured provider server serving the
+// ephemeral resource, whose service account api counts the resets of the
+// secret of the testServiceAccountID service account
+func testCredentialsServer(t *testing.T) (tfprotov5.ProviderServer, *int) {
+	var resets int
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/service_accounts/v1/"+testServiceAccountID+"/resetSecret" {
+			fakeapi.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "service account not found"})
+			return
+		}
+
+		resets++
+		fakeapi.WriteJSON(w, http.StatusOK, map[string]string{"id": testServiceAccountID, "clientId": "test-client-id", "secret": fmt.Sprintf("secret-%d", resets)})
+	}))
+	t.Cleanup(api.Close)
+
+	config := serviceAccounts.NewConfiguration()
+	config.Servers = serviceAccounts.ServerConfigurations{{URL: api.URL}}
+	config.HTTPClient = api.Client()
+
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		ServiceAccountClient: serviceAccounts.NewAPIClient(config),
+		HTTPClient:           api.Client(),
+		Localizer:            localizer,
+	})
+
+	server := providerserver.NewProtocol5(&testCredentialsProvider{factory: factory})()
+
+	providerConfig, err := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+	assert.NoError(t, err)
+
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	assert.NoError(t, err, "unexpected error configuring the provider")
+	assert.Empty(t, configureResp.Diagnostics, "got unexpected diagnostics")
+
+	return server, &resets
+}
+
+func testCredentialsConfig(t *testing.T, server tfprotov5.ProviderServer, id string, revokeOnClose interface{}) (*tfprotov5.DynamicValue, tftypes.Type) {
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	assert.NoError(t, err, "unexpected error getting the provider schema")
+
+	credentialsType := schemaResp.EphemeralResourceSchemas["rhoas_service_account_credentials"].ValueType()
+	config, err := tfprotov5.NewDynamicValue(credentialsType, tftypes.NewValue(credentialsType, map[string]tftypes.Value{
+		ServiceAccountIDField: tftypes.NewValue(tftypes.String, id),
+		RevokeOnCloseField:    tftypes.NewValue(tftypes.Bool, revokeOnClose),
+		ClientIDField:         tftypes.NewValue(tftypes.String, nil),
+		ClientSecret:          tftypes.NewValue(tftypes.String, nil),
+	}))
+	assert.NoError(t, err)
+
+	return &config, credentialsType
+}
+
+func TestServiceAccountCredentialsOpenClose(t *testing.T) {
+	tests := []struct {
+		name          string
+		revokeOnClose interface{}
+		wantRevoke    bool
+		wantResets    int
+	}{
+		{
+			name:          "revoke on close not set",
+			revokeOnClose: nil,
+			wantResets:    1,
+		},
+		{
+			name:          "revoke on close disabled",
+			revokeOnClose: false,
+			wantResets:    1,
+		},
+		{
+			name:          "revoke on close enabled",
+			revokeOnClose: true,
+			wantRevoke:    true,
+			wantResets:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			server, resets := testCredentialsServer(t)
+			config, credentialsType := testCredentialsConfig(t, server, testServiceAccountID, tt.revokeOnClose)
+
+			openResp, err := server.OpenEphemeralResource(context.Background(), &tfprotov5.OpenEphemeralResourceRequest{
+				TypeName: "rhoas_service_account_credentials",
+				Config:   config,
+			})
+			assert.NoError(t, err, "unexpected error opening the ephemeral resource")
+			if !assert.Empty(t, openResp.Diagnostics, "got unexpected diagnostics") {
+				return
+			}
+			assert.Equal(t, 1, *resets, "expected the secret to be reset once when opened")
+
+			result, err := openResp.Result.Unmarshal(credentialsType)
+			assert.NoError(t, err)
+
+			var attributes map[string]tftypes.Value
+			assert.NoError(t, result.As(&attributes))
+
+			var clientID, clientSecret string
+			var revoke bool
+			assert.NoError(t, attributes[ClientIDField].As(&clientID))
+			assert.NoError(t, attributes[ClientSecret].As(&clientSecret))
+			assert.NoError(t, attributes[RevokeOnCloseField].As(&revoke))
+			assert.Equal(t, "test-client-id", clientID)
+			assert.Equal(t, "secret-1", clientSecret)
+			assert.Equal(t, tt.wantRevoke, revoke)
+
+			closeResp, err := server.CloseEphemeralResource(context.Background(), &tfprotov5.CloseEphemeralResourceRequest{
+				TypeName: "rhoas_service_account_credentials",
+				Private:  openResp.Private,
+			})
+			assert.NoError(t, err, "unexpected error closing the ephemeral resource")
+			assert.Empty(t, closeResp.Diagnostics, "got unexpected diagnostics")
+			assert.Equal(t, tt.wantResets, *resets)
+		})
+	}
+}
+
+func TestServiceAccountCredentialsOpenMissing(t *testing.T) {
+	server, resets := testCredentialsServer(t)
+	config, _ := testCredentialsConfig(t, server, "missing-sa", true)
+
+	openResp, err := server.OpenEphemeralResource(context.Background(), &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "rhoas_service_account_credentials",
+		Config:   config,
+	})
+	assert.NoError(t, err, "unexpected error opening the ephemeral resource")
+	if assert.Len(t, openResp.Diagnostics, 1) {
+		assert.Equal(t, tfprotov5.DiagnosticSeverityError, openResp.Diagnostics[0].Severity)
+	}
+	assert.Empty(t, openResp.Private, "expected no secret to be revoked when closed")
+	assert.Equal(t, 0, *resets)
+}
@@ -0,0 +1,275 @@
+package smartevents
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+// nolint:funlen
+func ResourceBridge(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_bridge` manages a bridge in Red Hat OpenShift Smart Events, an endpoint receiving events which are routed by its processors.",
+		CreateContext: bridgeCreate,
+		ReadContext:   bridgeRead,
+		UpdateContext: bridgeUpdate,
+		DeleteContext: bridgeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.id"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			NameField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.name"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			CloudProviderField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.cloudProvider"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "aws",
+				ForceNew:    true,
+			},
+			RegionField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.region"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "us-east-1",
+				ForceNew:    true,
+			},
+			ErrorHandlerField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.errorHandler"),
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        actionSchema(localizer, ErrorHandlerField),
+			},
+			EndpointField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.endpoint"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			StatusField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.status"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OwnerField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.owner"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			SubmittedAtField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.submittedAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			PublishedAtField: {
+				Description: localizer.MustLocalize("smartevents.bridge.field.description.publishedAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// mapResourceDataToBridgeRequest builds the request to create or update the bridge of the resource
+func mapResourceDataToBridgeRequest(ctx context.Context, factory rhoasAPI.Factory, d *schema.ResourceData) (smarteventsmgmt.BridgeRequest, error) {
+	fields := map[string]string{}
+	for _, field := range []string{NameField, CloudProviderField, RegionField} {
+		value, ok := d.Get(field).(string)
+		if !ok {
+			return smarteventsmgmt.BridgeRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", field))
+		}
+
+		fields[field] = value
+	}
+
+	rawErrorHandler, ok := d.Get(ErrorHandlerField).([]interface{})
+	if !ok {
+		return smarteventsmgmt.BridgeRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ErrorHandlerField))
+	}
+
+	errorHandler, err := expandAction(ctx, factory, rawErrorHandler)
+	if err != nil {
+		return smarteventsmgmt.BridgeRequest{}, err
+	}
+
+	return smarteventsmgmt.BridgeRequest{
+		Name:          fields[NameField],
+		CloudProvider: fields[CloudProviderField],
+		Region:        fields[RegionField],
+		ErrorHandler:  errorHandler,
+	}, nil
+}
+
+func bridgeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	request, err := mapResourceDataToBridgeRequest(ctx, factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	bridge, resp, err := factory.SmartEventsMgmt().CreateBridge(ctx, request)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(bridge.ID)
+
+	if err = waitForBridgeReady(ctx, factory, bridge.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		// the failed bridge is kept in the state so that it is tainted
+		if diags := bridgeRead(ctx, d, m); diags.HasError() {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	return bridgeRead(ctx, d, m)
+}
+
+// waitForBridgeReady waits until the bridge with the given id is ready, failing
+// with the reason given by its status message when it fails
+func waitForBridgeReady(ctx context.Context, factory rhoasAPI.Factory, id string, timeout time.Duration) error {
+	readyStateConf := utils.NewStateChangeConf(
+		provisioningStatuses,
+		[]string{
+			StatusReady,
+		},
+		func() (interface{}, string, error) {
+			bridge, resp, err := factory.SmartEventsMgmt().GetBridge(ctx, id)
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			if bridge.Status == StatusFailed {
+				return bridge, bridge.Status, factory.Localizer().MustLocalizeError("smartevents.errors.bridgeFailed", localize.NewEntry("Name", bridge.Name), localize.NewEntry("Reason", bridge.StatusMessage))
+			}
+
+			return bridge, bridge.Status, nil
+		},
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	_, err := readyStateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func bridgeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	bridge, resp, err := factory.SmartEventsMgmt().GetBridge(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the bridge was deleted outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	// the error handler is not read back, the api redacts the secrets of its parameters
+	fields := map[string]interface{}{
+		NameField:          bridge.Name,
+		CloudProviderField: bridge.CloudProvider,
+		RegionField:        bridge.Region,
+		EndpointField:      bridge.Endpoint,
+		StatusField:        bridge.Status,
+		OwnerField:         bridge.Owner,
+		SubmittedAtField:   bridge.SubmittedAt,
+		PublishedAtField:   bridge.PublishedAt,
+	}
+
+	for field, value := range fields {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func bridgeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	if d.HasChange(ErrorHandlerField) {
+		request, err := mapResourceDataToBridgeRequest(ctx, factory, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, resp, err := factory.SmartEventsMgmt().UpdateBridge(ctx, d.Id(), request)
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return diag.FromErr(apiErr)
+		}
+
+		if err = waitForBridgeReady(ctx, factory, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return bridgeRead(ctx, d, m)
+}
+
+func bridgeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	resp, err := factory.SmartEventsMgmt().DeleteBridge(ctx, d.Id())
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	err = waitForDeleted(ctx, factory, func() (string, *http.Response, error) {
+		bridge, resp, err := factory.SmartEventsMgmt().GetBridge(ctx, d.Id())
+		return bridge.Status, resp, err
+	}, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,405 @@
+package smartevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	// separates the bridge id from the processor id in the id of the resource
+	idSeparator = "/"
+)
+
+// nolint:funlen
+func ResourceProcessor(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_processor` manages a processor of a bridge in Red Hat OpenShift Smart Events, which sends the events of the bridge matching its filters to its action, e.g. a Kafka topic.",
+		CreateContext: processorCreate,
+		ReadContext:   processorRead,
+		UpdateContext: processorUpdate,
+		DeleteContext: processorDelete,
+		CustomizeDiff: processorCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: processorImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			BridgeIDField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.bridgeID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			NameField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.name"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			FilterField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.filter"),
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        filterSchema(localizer),
+			},
+			TransformationTemplateField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.transformationTemplate"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			ActionField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.action"),
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        actionSchema(localizer, ActionField),
+			},
+			StatusField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.status"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OwnerField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.owner"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			SubmittedAtField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.submittedAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			PublishedAtField: {
+				Description: localizer.MustLocalize("smartevents.processor.field.description.publishedAt"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// processorCustomizeDiff checks that the filters have a single value for the
+// StringEquals type and a list of values otherwise
+func processorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return fmt.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	if !d.NewValueKnown(FilterField) {
+		return nil
+	}
+
+	rawFilters, _ := d.Get(FilterField).([]interface{})
+	for i, filter := range expandFilters(rawFilters) {
+		// the values of the filter may only be known once applied
+		prefix := fmt.Sprintf("%s.%d.", FilterField, i)
+		if !d.NewValueKnown(prefix+ValueField) || !d.NewValueKnown(prefix+ValuesField) {
+			continue
+		}
+
+		singleValued := filter.Type == StringEqualsFilter
+		if singleValued && (filter.Value == "" || len(filter.Values) > 0) {
+			return factory.Localizer().MustLocalizeError("smartevents.errors.filterValue", localize.NewEntry("Key", filter.Key), localize.NewEntry("Type", filter.Type))
+		}
+
+		if !singleValued && (filter.Value != "" || len(filter.Values) == 0) {
+			return factory.Localizer().MustLocalizeError("smartevents.errors.filterValues", localize.NewEntry("Key", filter.Key), localize.NewEntry("Type", filter.Type))
+		}
+	}
+
+	return nil
+}
+
+func expandFilters(rawFilters []interface{}) []smarteventsmgmt.Filter {
+	filters := make([]smarteventsmgmt.Filter, 0, len(rawFilters))
+
+	for _, rawFilter := range rawFilters {
+		filter, ok := rawFilter.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		filterType, _ := filter[TypeField].(string)
+		key, _ := filter[KeyField].(string)
+		value, _ := filter[ValueField].(string)
+		rawValues, _ := filter[ValuesField].([]interface{})
+
+		values := make([]string, len(rawValues))
+		for i, v := range rawValues {
+			values[i], _ = v.(string)
+		}
+
+		filters = append(filters, smarteventsmgmt.Filter{
+			Type:   filterType,
+			Key:    key,
+			Value:  value,
+			Values: values,
+		})
+	}
+
+	return filters
+}
+
+func flattenFilters(filters []smarteventsmgmt.Filter) []interface{} {
+	fs := make([]interface{}, len(filters))
+
+	for i, filter := range filters {
+		fs[i] = map[string]interface{}{
+			TypeField:   filter.Type,
+			KeyField:    filter.Key,
+			ValueField:  filter.Value,
+			ValuesField: filter.Values,
+		}
+	}
+
+	return fs
+}
+
+// mapResourceDataToProcessorRequest builds the request to create or update the processor of the resource
+func mapResourceDataToProcessorRequest(ctx context.Context, factory rhoasAPI.Factory, d *schema.ResourceData) (smarteventsmgmt.ProcessorRequest, error) {
+	name, ok := d.Get(NameField).(string)
+	if !ok {
+		return smarteventsmgmt.ProcessorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", NameField))
+	}
+
+	rawFilters, ok := d.Get(FilterField).([]interface{})
+	if !ok {
+		return smarteventsmgmt.ProcessorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", FilterField))
+	}
+
+	transformationTemplate, ok := d.Get(TransformationTemplateField).(string)
+	if !ok {
+		return smarteventsmgmt.ProcessorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TransformationTemplateField))
+	}
+
+	rawAction, ok := d.Get(ActionField).([]interface{})
+	if !ok {
+		return smarteventsmgmt.ProcessorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ActionField))
+	}
+
+	action, err := expandAction(ctx, factory, rawAction)
+	if err != nil {
+		return smarteventsmgmt.ProcessorRequest{}, err
+	}
+
+	if action == nil {
+		return smarteventsmgmt.ProcessorRequest{}, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", ActionField))
+	}
+
+	return smarteventsmgmt.ProcessorRequest{
+		Name:                   name,
+		Filters:                expandFilters(rawFilters),
+		TransformationTemplate: transformationTemplate,
+		Action:                 *action,
+	}, nil
+}
+
+// parseProcessorID returns the bridge id and the processor id of the id of the resource
+func parseProcessorID(id string) (string, string, error) {
+	bridgeID, processorID, found := strings.Cut(id, idSeparator)
+	if !found || bridgeID == "" || processorID == "" {
+		return "", "", fmt.Errorf("unexpected id %q, expected <bridge_id>%s<processor_id>", id, idSeparator)
+	}
+
+	return bridgeID, processorID, nil
+}
+
+func processorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	bridgeID, ok := d.Get(BridgeIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", BridgeIDField)))
+	}
+
+	request, err := mapResourceDataToProcessorRequest(ctx, factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	processor, resp, err := factory.SmartEventsMgmt().CreateProcessor(ctx, bridgeID, request)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	d.SetId(bridgeID + idSeparator + processor.ID)
+
+	if err = waitForProcessorReady(ctx, factory, bridgeID, processor.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		// the failed processor is kept in the state so that it is tainted
+		if diags := processorRead(ctx, d, m); diags.HasError() {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	return processorRead(ctx, d, m)
+}
+
+// waitForProcessorReady waits until the processor is ready, failing with the
+// reason given by its status message when it fails
+func waitForProcessorReady(ctx context.Context, factory rhoasAPI.Factory, bridgeID string, id string, timeout time.Duration) error {
+	readyStateConf := utils.NewStateChangeConf(
+		provisioningStatuses,
+		[]string{
+			StatusReady,
+		},
+		func() (interface{}, string, error) {
+			processor, resp, err := factory.SmartEventsMgmt().GetProcessor(ctx, bridgeID, id)
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			if processor.Status == StatusFailed {
+				return processor, processor.Status, factory.Localizer().MustLocalizeError("smartevents.errors.processorFailed", localize.NewEntry("Name", processor.Name), localize.NewEntry("Reason", processor.StatusMessage))
+			}
+
+			return processor, processor.Status, nil
+		},
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	_, err := readyStateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func processorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	bridgeID, id, err := parseProcessorID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	processor, resp, err := factory.SmartEventsMgmt().GetProcessor(ctx, bridgeID, id)
+	if resp != nil && utils.CheckNotFound(resp) {
+		// the processor was deleted outside of terraform
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	// the action is not read back, the api redacts the secrets of its parameters
+	fields := map[string]interface{}{
+		BridgeIDField:               bridgeID,
+		NameField:                   processor.Name,
+		FilterField:                 flattenFilters(processor.Filters),
+		TransformationTemplateField: processor.TransformationTemplate,
+		StatusField:                 processor.Status,
+		OwnerField:                  processor.Owner,
+		SubmittedAtField:            processor.SubmittedAt,
+		PublishedAtField:            processor.PublishedAt,
+	}
+
+	for field, value := range fields {
+		if err = d.Set(field, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func processorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	bridgeID, id, err := parseProcessorID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := mapResourceDataToProcessorRequest(ctx, factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, resp, err := factory.SmartEventsMgmt().UpdateProcessor(ctx, bridgeID, id, request)
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = waitForProcessorReady(ctx, factory, bridgeID, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return processorRead(ctx, d, m)
+}
+
+func processorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	bridgeID, id, err := parseProcessorID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := factory.SmartEventsMgmt().DeleteProcessor(ctx, bridgeID, id)
+	if resp != nil && utils.CheckNotFound(resp) {
+		d.SetId("")
+		return diags
+	}
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	err = waitForDeleted(ctx, factory, func() (string, *http.Response, error) {
+		processor, resp, err := factory.SmartEventsMgmt().GetProcessor(ctx, bridgeID, id)
+		return processor.Status, resp, err
+	}, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// processorImport imports a processor from an id of the form <bridge_id>/<processor_id>
+func processorImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	bridgeID, _, err := parseProcessorID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(BridgeIDField, bridgeID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,244 @@
+package smartevents
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	IDField                     = "id"
+	NameField                   = "name"
+	CloudProviderField          = "cloud_provider"
+	RegionField                 = "region"
+	ErrorHandlerField           = "error_handler"
+	EndpointField               = "endpoint"
+	BridgeIDField               = "bridge_id"
+	FilterField                 = "filter"
+	TransformationTemplateField = "transformation_template"
+	ActionField                 = "action"
+	StatusField                 = "status"
+	OwnerField                  = "owner"
+	SubmittedAtField            = "submitted_at"
+	PublishedAtField            = "published_at"
+
+	TypeField         = "type"
+	ParametersField   = "parameters"
+	KafkaTopicField   = "kafka_topic"
+	KafkaIDField      = "kafka_id"
+	TopicField        = "topic"
+	ClientIDField     = "client_id"
+	ClientSecretField = "client_secret"
+	KeyField          = "key"
+	ValueField        = "value"
+	ValuesField       = "values"
+
+	// KafkaTopicSinkAction is the type of the actions sending events to a Kafka topic
+	KafkaTopicSinkAction = "kafka_topic_sink_0.1"
+
+	// StringEqualsFilter is the only type of filter matching a single value
+	StringEqualsFilter = "StringEquals"
+
+	StatusReady   = "ready"
+	StatusFailed  = "failed"
+	StatusDeleted = "deleted"
+)
+
+// FilterTypes are the types of the filters of a processor
+var FilterTypes = []string{StringEqualsFilter, "StringBeginsWith", "StringContains", "StringIn"}
+
+// provisioningStatuses are the statuses of a bridge or processor on its way to ready
+var provisioningStatuses = []string{"accepted", "preparing", "provisioning"}
+
+// deletionStatuses are the statuses of a bridge or processor on its way to deleted
+var deletionStatuses = []string{"deprovision", "deleting"}
+
+// actionSchema returns the schema of an action, given either as a type with its
+// parameters or as the Kafka topic to send the events to. The field is the
+// field of the resource holding the action, for the fields to exclude each other
+func actionSchema(localizer localize.Localizer, field string) *schema.Resource {
+	typePath := field + ".0." + TypeField
+	kafkaTopicPath := field + ".0." + KafkaTopicField
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			TypeField: {
+				Description:  localizer.MustLocalize("smartevents.action.field.description.type"),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{typePath, kafkaTopicPath},
+			},
+			ParametersField: {
+				Description:   localizer.MustLocalize("smartevents.action.field.description.parameters"),
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{kafkaTopicPath},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			KafkaTopicField: {
+				Description:  localizer.MustLocalize("smartevents.action.field.description.kafkaTopic"),
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{typePath, kafkaTopicPath},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						KafkaIDField: {
+							Description: localizer.MustLocalize("smartevents.action.field.description.kafkaID"),
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						TopicField: {
+							Description: localizer.MustLocalize("smartevents.action.field.description.topic"),
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						ClientIDField: {
+							Description: localizer.MustLocalize("smartevents.action.field.description.clientID"),
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						ClientSecretField: {
+							Description: localizer.MustLocalize("smartevents.action.field.description.clientSecret"),
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filterSchema returns the schema of the filters of a processor
+func filterSchema(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			TypeField: {
+				Description:  localizer.MustLocalize("smartevents.filter.field.description.type"),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(FilterTypes, false),
+			},
+			KeyField: {
+				Description: localizer.MustLocalize("smartevents.filter.field.description.key"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			ValueField: {
+				Description: localizer.MustLocalize("smartevents.filter.field.description.value"),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			ValuesField: {
+				Description: localizer.MustLocalize("smartevents.filter.field.description.values"),
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// expandAction converts the action block of a resource into the action of the
+// api, looking up the bootstrap server of the Kafka instance of a Kafka topic
+func expandAction(ctx context.Context, factory rhoasAPI.Factory, raw []interface{}) (*smarteventsmgmt.Action, error) {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+
+	action, _ := raw[0].(map[string]interface{})
+
+	if kafkaTopics, _ := action[KafkaTopicField].([]interface{}); len(kafkaTopics) > 0 && kafkaTopics[0] != nil {
+		kafkaTopic, _ := kafkaTopics[0].(map[string]interface{})
+		kafkaID, _ := kafkaTopic[KafkaIDField].(string)
+
+		bootstrapServer, err := getKafkaBootstrapServer(ctx, factory, kafkaID)
+		if err != nil {
+			return nil, err
+		}
+
+		topic, _ := kafkaTopic[TopicField].(string)
+		clientID, _ := kafkaTopic[ClientIDField].(string)
+		clientSecret, _ := kafkaTopic[ClientSecretField].(string)
+
+		return &smarteventsmgmt.Action{
+			Type: KafkaTopicSinkAction,
+			Parameters: map[string]string{
+				"topic":               topic,
+				"kafka_broker_url":    bootstrapServer,
+				"kafka_client_id":     clientID,
+				"kafka_client_secret": clientSecret,
+			},
+		}, nil
+	}
+
+	actionType, _ := action[TypeField].(string)
+	rawParameters, _ := action[ParametersField].(map[string]interface{})
+
+	parameters := make(map[string]string, len(rawParameters))
+	for key, value := range rawParameters {
+		parameters[key], _ = value.(string)
+	}
+
+	return &smarteventsmgmt.Action{
+		Type:       actionType,
+		Parameters: parameters,
+	}, nil
+}
+
+// getKafkaBootstrapServer returns the bootstrap server of the Kafka instance with the given id
+func getKafkaBootstrapServer(ctx context.Context, factory rhoasAPI.Factory, kafkaID string) (string, error) {
+	kafka, resp, err := factory.KafkaMgmt().GetKafkaById(ctx, kafkaID).Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return "", apiErr
+	}
+
+	if kafka.GetBootstrapServerHost() == "" {
+		return "", factory.Localizer().MustLocalizeError("smartevents.errors.kafkaNotReady", localize.NewEntry("Name", kafka.GetName()))
+	}
+
+	return kafka.GetBootstrapServerHost(), nil
+}
+
+// waitForDeleted waits until the bridge or processor whose status is returned
+// by getStatus is deleted, i.e. until it is not found anymore
+func waitForDeleted(ctx context.Context, factory rhoasAPI.Factory, getStatus func() (string, *http.Response, error), timeout time.Duration) error {
+	deleteStateConf := utils.NewStateChangeConf(
+		append([]string{StatusReady, StatusFailed}, deletionStatuses...),
+		[]string{
+			StatusDeleted,
+		},
+		func() (interface{}, string, error) {
+			status, resp, err := getStatus()
+			if resp != nil && utils.CheckNotFound(resp) {
+				return StatusDeleted, StatusDeleted, nil
+			}
+			if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+
+			return status, status, nil
+		},
+		timeout,
+		factory.PollInterval(),
+		factory.PollMaxInterval(),
+	)
+
+	_, err := deleteStateConf.WaitForStateContext(ctx)
+
+	return err
+}
@@ -0,0 +1,353 @@
+package smartevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/smarteventsmgmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSmartEvents is an in memory smart events management api. Every read of
+// a bridge or processor advances it to its next status, it is then ready
+// unless it failed. Deleted ones are gone after the first read
+type fakeSmartEvents struct {
+	bridges    map[string]*smarteventsmgmt.Bridge
+	processors map[string]*smarteventsmgmt.Processor
+	statuses   map[string][]string
+	requests   []interface{}
+}
+
+func (f *fakeSmartEvents) nextStatus(name string) string {
+	statuses := f.statuses[name]
+	if len(statuses) == 0 {
+		return StatusReady
+	}
+
+	f.statuses[name] = statuses[1:]
+	return statuses[0]
+}
+
+func (f *fakeSmartEvents) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const bridgesPath = "/api/smartevents_mgmt/v1/bridges/"
+
+	writeJSON := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	notFound := map[string]string{"code": "OPENBRIDGE-4", "reason": "not found"}
+
+	if strings.HasPrefix(r.URL.Path, "/api/kafkas_mgmt/") {
+		kafka := kafkamgmtclient.NewKafkaRequest("test-kafka-id", "Kafka", "", true, true)
+		kafka.SetName("test-kafka")
+		kafka.SetStatus("ready")
+		kafka.SetBootstrapServerHost("test-kafka.example.com:443")
+		writeJSON(http.StatusOK, kafka)
+		return
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == strings.TrimSuffix(bridgesPath, "/") {
+		var request smarteventsmgmt.BridgeRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		f.requests = append(f.requests, request)
+		bridge := &smarteventsmgmt.Bridge{ID: "bridge-" + request.Name, Name: request.Name, CloudProvider: request.CloudProvider, Region: request.Region, Status: "accepted"}
+		f.bridges[bridge.ID] = bridge
+		writeJSON(http.StatusAccepted, bridge)
+		return
+	}
+
+	bridgeID, processorPath, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, bridgesPath), "/processors")
+	bridge, ok := f.bridges[bridgeID]
+	if !ok {
+		writeJSON(http.StatusNotFound, notFound)
+		return
+	}
+
+	if processorPath == "" && !strings.HasSuffix(r.URL.Path, "/processors") {
+		switch r.Method {
+		case http.MethodDelete:
+			bridge.Status = "deprovision"
+			w.WriteHeader(http.StatusAccepted)
+			return
+		case http.MethodPut:
+			var request smarteventsmgmt.BridgeRequest
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			f.requests = append(f.requests, request)
+			bridge.Status = "accepted"
+			writeJSON(http.StatusAccepted, bridge)
+			return
+		}
+
+		if bridge.Status == "deprovision" {
+			delete(f.bridges, bridge.ID)
+		} else if bridge.Status != StatusFailed {
+			bridge.Status = f.nextStatus(bridge.Name)
+			if bridge.Status == StatusFailed {
+				bridge.StatusMessage = "no capacity left in the region"
+			}
+			bridge.Endpoint = "https://" + bridge.ID + ".example.com/events"
+		}
+		writeJSON(http.StatusOK, bridge)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var request smarteventsmgmt.ProcessorRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		f.requests = append(f.requests, request)
+		processor := &smarteventsmgmt.Processor{ID: "processor-" + request.Name, Name: request.Name, Filters: request.Filters, TransformationTemplate: request.TransformationTemplate, Action: request.Action, Status: "accepted"}
+		f.processors[processor.ID] = processor
+		writeJSON(http.StatusAccepted, processor)
+		return
+	}
+
+	processor, ok := f.processors[strings.TrimPrefix(processorPath, "/")]
+	if !ok {
+		writeJSON(http.StatusNotFound, notFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		processor.Status = "deprovision"
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case http.MethodPut:
+		var request smarteventsmgmt.ProcessorRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		f.requests = append(f.requests, request)
+		processor.Filters, processor.TransformationTemplate, processor.Action = request.Filters, request.TransformationTemplate, request.Action
+		processor.Status = "accepted"
+		writeJSON(http.StatusAccepted, processor)
+		return
+	}
+
+	if processor.Status == "deprovision" {
+		delete(f.processors, processor.ID)
+	} else {
+		processor.Status = f.nextStatus(processor.Name)
+	}
+	writeJSON(http.StatusOK, processor)
+}
+
+// testSmartEventsFactory returns a factory whose kafka and smart events
+// management apis are a local fake, along with the fake to inspect its requests
+func testSmartEventsFactory(t *testing.T, statuses map[string][]string) (rhoasAPI.Factory, *fakeSmartEvents) {
+	localizer, _ := goi18n.New(nil)
+
+	fake := &fakeSmartEvents{
+		bridges:    map[string]*smarteventsmgmt.Bridge{},
+		processors: map[string]*smarteventsmgmt.Processor{},
+		statuses:   statuses,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	return factories.NewDefaultFactory(factories.Options{
+		KafkaClient:       kafkaClient,
+		SmartEventsClient: smarteventsmgmt.NewAPIClient(server.Client(), server.URL),
+		HTTPClient:        server.Client(),
+		Localizer:         localizer,
+	}), fake
+}
+
+func testKafkaTopicAction() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			KafkaTopicField: []interface{}{
+				map[string]interface{}{
+					KafkaIDField:      "test-kafka-id",
+					TopicField:        "orders",
+					ClientIDField:     "test-client-id",
+					ClientSecretField: "test-client-secret",
+				},
+			},
+		},
+	}
+}
+
+func TestResourceBridgeLifecycle(t *testing.T) {
+	factory, fake := testSmartEventsFactory(t, map[string][]string{
+		"test-bridge": {"preparing", "provisioning"},
+	})
+
+	r := ResourceBridge(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField:         "test-bridge",
+		ErrorHandlerField: testKafkaTopicAction(),
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "bridge-test-bridge", d.Id())
+	assert.Equal(t, StatusReady, d.Get(StatusField))
+	assert.Equal(t, "https://bridge-test-bridge.example.com/events", d.Get(EndpointField))
+	assert.Equal(t, smarteventsmgmt.BridgeRequest{
+		Name:          "test-bridge",
+		CloudProvider: "aws",
+		Region:        "us-east-1",
+		ErrorHandler: &smarteventsmgmt.Action{
+			Type: KafkaTopicSinkAction,
+			Parameters: map[string]string{
+				"topic":               "orders",
+				"kafka_broker_url":    "test-kafka.example.com:443",
+				"kafka_client_id":     "test-client-id",
+				"kafka_client_secret": "test-client-secret",
+			},
+		},
+	}, fake.requests[0])
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.bridges)
+}
+
+func TestResourceBridgeCreationFailure(t *testing.T) {
+	factory, _ := testSmartEventsFactory(t, map[string][]string{
+		"test-bridge": {"provisioning", StatusFailed},
+	})
+
+	r := ResourceBridge(factory.Localizer())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		NameField: "test-bridge",
+	})
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "no capacity left in the region")
+	assert.Equal(t, "bridge-test-bridge", d.Id(), "expected the failed bridge to be kept so it is tainted")
+	assert.Equal(t, StatusFailed, d.Get(StatusField))
+}
+
+func TestResourceProcessorLifecycle(t *testing.T) {
+	factory, fake := testSmartEventsFactory(t, nil)
+	fake.bridges["test-bridge"] = &smarteventsmgmt.Bridge{ID: "test-bridge", Name: "test-bridge", Status: StatusReady}
+
+	r := ResourceProcessor(factory.Localizer())
+	config := map[string]interface{}{
+		BridgeIDField: "test-bridge",
+		NameField:     "orders",
+		FilterField: []interface{}{
+			map[string]interface{}{TypeField: StringEqualsFilter, KeyField: "source", ValueField: "shop"},
+			map[string]interface{}{TypeField: "StringIn", KeyField: "data.country", ValuesField: []interface{}{"FR", "DE"}},
+		},
+		ActionField: testKafkaTopicAction(),
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	diags := r.CreateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "test-bridge/processor-orders", d.Id())
+	assert.Equal(t, StatusReady, d.Get(StatusField))
+	assert.Equal(t, []smarteventsmgmt.Filter{
+		{Type: StringEqualsFilter, Key: "source", Value: "shop"},
+		{Type: "StringIn", Key: "data.country", Values: []string{"FR", "DE"}},
+	}, fake.requests[0].(smarteventsmgmt.ProcessorRequest).Filters)
+	assert.Equal(t, "DE", d.Get(FilterField+".1."+ValuesField+".1"))
+
+	config[TransformationTemplateField] = "{data.name} ordered {data.count} items"
+	d = testUpdateResourceData(t, r, factory, d.State(), config)
+
+	diags = r.UpdateContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "{data.name} ordered {data.count} items", fake.processors["processor-orders"].TransformationTemplate)
+	assert.Equal(t, KafkaTopicSinkAction, fake.processors["processor-orders"].Action.Type)
+
+	diags = r.DeleteContext(context.Background(), d, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.processors)
+}
+
+func TestResourceProcessorFilterValidation(t *testing.T) {
+	factory, _ := testSmartEventsFactory(t, nil)
+	r := ResourceProcessor(factory.Localizer())
+
+	tests := []struct {
+		name    string
+		filter  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "StringEquals with a value",
+			filter: map[string]interface{}{TypeField: StringEqualsFilter, KeyField: "source", ValueField: "shop"},
+		},
+		{
+			name:    "StringEquals with values",
+			filter:  map[string]interface{}{TypeField: StringEqualsFilter, KeyField: "source", ValuesField: []interface{}{"shop"}},
+			wantErr: true,
+		},
+		{
+			name:   "StringBeginsWith with values",
+			filter: map[string]interface{}{TypeField: "StringBeginsWith", KeyField: "source", ValuesField: []interface{}{"shop"}},
+		},
+		{
+			name:    "StringContains with a value",
+			filter:  map[string]interface{}{TypeField: "StringContains", KeyField: "source", ValueField: "shop"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				BridgeIDField: "test-bridge",
+				NameField:     "orders",
+				FilterField:   []interface{}{tt.filter},
+				ActionField: []interface{}{
+					map[string]interface{}{TypeField: "webhook_sink_0.1", ParametersField: map[string]interface{}{"endpoint": "https://example.com"}},
+				},
+			}
+
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), factory)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseProcessorID(t *testing.T) {
+	bridgeID, processorID, err := parseProcessorID("test-bridge/test-processor")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-bridge", bridgeID)
+	assert.Equal(t, "test-processor", processorID)
+
+	_, _, err = parseProcessorID("test-processor")
+	assert.Error(t, err)
+}
+
+func testUpdateResourceData(t *testing.T, r *schema.Resource, factory rhoasAPI.Factory, state *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), factory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
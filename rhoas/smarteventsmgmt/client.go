@@ -0,0 +1,173 @@
+package smarteventsmgmt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
+)
+
+const (
+	basePath = "/api/smartevents_mgmt/v1"
+)
+
+// APIClient calls the Red Hat OpenShift Smart Events management api. It stands
+// in for the smarteventsmgmt module of the app services sdk, which the provider
+// does not depend on yet
+type APIClient struct {
+	client *restapi.Client
+}
+
+// Action is where a processor sends the events it matches, or where a bridge
+// sends the events it fails to process, e.g. a Kafka topic or a webhook
+type Action struct {
+	Type       string            `json:"type"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+// BridgeRequest is the request to create or update a bridge
+type BridgeRequest struct {
+	Name          string  `json:"name"`
+	CloudProvider string  `json:"cloud_provider"`
+	Region        string  `json:"region"`
+	ErrorHandler  *Action `json:"error_handler,omitempty"`
+}
+
+// Bridge is an ingress endpoint events are sent to, which are then routed by its processors
+type Bridge struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Endpoint      string  `json:"endpoint,omitempty"`
+	CloudProvider string  `json:"cloud_provider"`
+	Region        string  `json:"region"`
+	ErrorHandler  *Action `json:"error_handler,omitempty"`
+	Status        string  `json:"status"`
+	StatusMessage string  `json:"status_message,omitempty"`
+	Owner         string  `json:"owner,omitempty"`
+	SubmittedAt   string  `json:"submitted_at,omitempty"`
+	PublishedAt   string  `json:"published_at,omitempty"`
+}
+
+// Filter matches the events whose attribute or data at the key has one of the values
+type Filter struct {
+	Type   string   `json:"type"`
+	Key    string   `json:"key"`
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// ProcessorRequest is the request to create or update a processor
+type ProcessorRequest struct {
+	Name                   string   `json:"name"`
+	Filters                []Filter `json:"filters,omitempty"`
+	TransformationTemplate string   `json:"transformationTemplate,omitempty"`
+	Action                 Action   `json:"action"`
+}
+
+// Processor sends the events of a bridge matching its filters to its action,
+// transformed by its template
+type Processor struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Filters                []Filter `json:"filters,omitempty"`
+	TransformationTemplate string   `json:"transformationTemplate,omitempty"`
+	Action                 Action   `json:"action"`
+	Status                 string   `json:"status"`
+	StatusMessage          string   `json:"status_message,omitempty"`
+	Owner                  string   `json:"owner,omitempty"`
+	SubmittedAt            string   `json:"submitted_at,omitempty"`
+	PublishedAt            string   `json:"published_at,omitempty"`
+}
+
+// API is the Smart Events management api used by the provider. The factory
+// returns it rather than the APIClient so that it can be replaced in tests
+type API interface {
+	CreateBridge(ctx context.Context, bridge BridgeRequest) (Bridge, *http.Response, error)
+	GetBridge(ctx context.Context, id string) (Bridge, *http.Response, error)
+	UpdateBridge(ctx context.Context, id string, bridge BridgeRequest) (Bridge, *http.Response, error)
+	DeleteBridge(ctx context.Context, id string) (*http.Response, error)
+	CreateProcessor(ctx context.Context, bridgeID string, processor ProcessorRequest) (Processor, *http.Response, error)
+	GetProcessor(ctx context.Context, bridgeID string, id string) (Processor, *http.Response, error)
+	UpdateProcessor(ctx context.Context, bridgeID string, id string, processor ProcessorRequest) (Processor, *http.Response, error)
+	DeleteProcessor(ctx context.Context, bridgeID string, id string) (*http.Response, error)
+}
+
+var _ API = &APIClient{}
+
+func NewAPIClient(httpClient *http.Client, baseURL string) *APIClient {
+	return &APIClient{
+		client: restapi.NewClient(httpClient, baseURL),
+	}
+}
+
+// CreateBridge requests a new bridge, which is provisioned asynchronously
+func (c *APIClient) CreateBridge(ctx context.Context, bridge BridgeRequest) (Bridge, *http.Response, error) {
+	var created Bridge
+	resp, err := c.client.Do(ctx, http.MethodPost, basePath+"/bridges", nil, bridge, &created)
+
+	return created, resp, err
+}
+
+// GetBridge returns the bridge with the given id
+func (c *APIClient) GetBridge(ctx context.Context, id string) (Bridge, *http.Response, error) {
+	var bridge Bridge
+	resp, err := c.client.Get(ctx, bridgePath(id), nil, &bridge)
+
+	return bridge, resp, err
+}
+
+// UpdateBridge replaces the error handler of the bridge with the given id,
+// which is provisioned again asynchronously
+func (c *APIClient) UpdateBridge(ctx context.Context, id string, bridge BridgeRequest) (Bridge, *http.Response, error) {
+	var updated Bridge
+	resp, err := c.client.Do(ctx, http.MethodPut, bridgePath(id), nil, bridge, &updated)
+
+	return updated, resp, err
+}
+
+// DeleteBridge requests the deletion of the bridge with the given id, which
+// is deleted asynchronously. A bridge can only be deleted without processors
+func (c *APIClient) DeleteBridge(ctx context.Context, id string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, bridgePath(id), nil, nil, nil)
+}
+
+// CreateProcessor requests a new processor of the bridge with the given id,
+// which is provisioned asynchronously
+func (c *APIClient) CreateProcessor(ctx context.Context, bridgeID string, processor ProcessorRequest) (Processor, *http.Response, error) {
+	var created Processor
+	resp, err := c.client.Do(ctx, http.MethodPost, bridgePath(bridgeID)+"/processors", nil, processor, &created)
+
+	return created, resp, err
+}
+
+// GetProcessor returns the processor with the given id of the bridge with the given id
+func (c *APIClient) GetProcessor(ctx context.Context, bridgeID string, id string) (Processor, *http.Response, error) {
+	var processor Processor
+	resp, err := c.client.Get(ctx, processorPath(bridgeID, id), nil, &processor)
+
+	return processor, resp, err
+}
+
+// UpdateProcessor replaces the filters, transformation and action of the
+// processor, which is provisioned again asynchronously
+func (c *APIClient) UpdateProcessor(ctx context.Context, bridgeID string, id string, processor ProcessorRequest) (Processor, *http.Response, error) {
+	var updated Processor
+	resp, err := c.client.Do(ctx, http.MethodPut, processorPath(bridgeID, id), nil, processor, &updated)
+
+	return updated, resp, err
+}
+
+// DeleteProcessor requests the deletion of the processor, which is deleted asynchronously
+func (c *APIClient) DeleteProcessor(ctx context.Context, bridgeID string, id string) (*http.Response, error) {
+	return c.client.Do(ctx, http.MethodDelete, processorPath(bridgeID, id), nil, nil, nil)
+}
+
+func bridgePath(id string) string {
+	return fmt.Sprintf("%s/bridges/%s", basePath, url.PathEscape(id))
+}
+
+func processorPath(bridgeID string, id string) string {
+	return fmt.Sprintf("%s/processors/%s", bridgePath(bridgeID), url.PathEscape(id))
+}
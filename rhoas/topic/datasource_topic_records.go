@@ -0,0 +1,255 @@
+package topic
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	RecordsField        = "records"
+	TimestampField      = "timestamp"
+	TimestampTypeField  = "timestamp_type"
+	LimitField          = "limit"
+	MaxValueLengthField = "max_value_length"
+	Base64Field         = "base64"
+
+	// the number of records consumed when no limit is given
+	defaultRecordsLimit = 20
+
+	// consumes records from the latest records when no offset is given
+	anyOffset = -1
+)
+
+// nolint:funlen
+func DataSourceTopicRecords(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_topic_records` provides a bounded number of records consumed from a topic of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, e.g. to verify that records exist after an apply.",
+		ReadContext: dataSourceTopicRecordsRead,
+		Schema: map[string]*schema.Schema{
+			KafkaIDField: {
+				Description: localizer.MustLocalize("topic.resource.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			TopicField: {
+				Description: localizer.MustLocalize("topic.records.datasource.field.description.topic"),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			PartitionField: {
+				Description:  localizer.MustLocalize("topic.records.datasource.field.description.partition"),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      anyPartition,
+				ValidateFunc: validation.IntAtLeast(anyPartition),
+			},
+			OffsetField: {
+				Description:   localizer.MustLocalize("topic.records.datasource.field.description.offset"),
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Default:       anyOffset,
+				ValidateFunc:  validation.IntAtLeast(anyOffset),
+				RequiredWith:  []string{PartitionField},
+				ConflictsWith: []string{TimestampField},
+			},
+			TimestampField: {
+				Description:  localizer.MustLocalize("topic.records.datasource.field.description.timestamp"),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			LimitField: {
+				Description:  localizer.MustLocalize("topic.records.datasource.field.description.limit"),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultRecordsLimit,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			MaxValueLengthField: {
+				Description:  localizer.MustLocalize("topic.records.datasource.field.description.maxValueLength"),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			Base64Field: {
+				Description: localizer.MustLocalize("topic.records.datasource.field.description.base64"),
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			RecordsField: {
+				Description: localizer.MustLocalize("topic.records.datasource.field.description.records"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						PartitionField: {
+							Description: localizer.MustLocalize("topic.records.field.description.publishedPartition"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						OffsetField: {
+							Description: localizer.MustLocalize("topic.records.field.description.offset"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						TimestampField: {
+							Description: localizer.MustLocalize("topic.records.datasource.field.description.recordTimestamp"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						TimestampTypeField: {
+							Description: localizer.MustLocalize("topic.records.datasource.field.description.timestampType"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						KeyField: {
+							Description: localizer.MustLocalize("topic.records.field.description.key"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ValueField: {
+							Description: localizer.MustLocalize("topic.records.field.description.value"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						HeadersField: {
+							Description: localizer.MustLocalize("topic.records.field.description.headers"),
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTopicRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
+	}
+
+	topic, ok := d.Get(TopicField).(string)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TopicField)))
+	}
+
+	encode, ok := d.Get(Base64Field).(bool)
+	if !ok {
+		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", Base64Field)))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := buildConsumeRecordsRequest(factory, d, instanceAPI.RecordsApi.ConsumeRecords(ctx, topic))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list, resp, err := request.Execute()
+	if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+		return diag.FromErr(apiErr)
+	}
+
+	if err = d.Set(RecordsField, flattenRecords(list.GetItems(), encode)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", kafkaID, topic))
+
+	return diags
+}
+
+// buildConsumeRecordsRequest sets the optional starting point and bounds of
+// the consumed records on the request
+func buildConsumeRecordsRequest(factory rhoasAPI.Factory, d *schema.ResourceData, request kafkainstanceclient.ApiConsumeRecordsRequest) (kafkainstanceclient.ApiConsumeRecordsRequest, error) {
+	limit, ok := d.Get(LimitField).(int)
+	if !ok {
+		return request, factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", LimitField))
+	}
+	request = request.Limit(int32(limit))
+
+	if partition, _ := d.Get(PartitionField).(int); partition != anyPartition {
+		request = request.Partition(int32(partition))
+	}
+
+	if offset, _ := d.Get(OffsetField).(int); offset != anyOffset {
+		request = request.Offset(int32(offset))
+	}
+
+	if maxValueLength, _ := d.Get(MaxValueLengthField).(int); maxValueLength > 0 {
+		request = request.MaxValueLength(int32(maxValueLength))
+	}
+
+	if timestamp, _ := d.Get(TimestampField).(string); timestamp != "" {
+		// the timestamp is validated by the schema
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return request, err
+		}
+		request = request.Timestamp(t)
+	}
+
+	return request, nil
+}
+
+func flattenRecords(records []kafkainstanceclient.Record, encode bool) []interface{} {
+	rs := make([]interface{}, len(records))
+
+	for i, record := range records {
+		key := record.GetKey()
+		value := record.GetValue()
+		if encode {
+			key = base64.StdEncoding.EncodeToString([]byte(key))
+			value = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+
+		headers := map[string]interface{}{}
+		for name, headerValue := range record.GetHeaders() {
+			headers[name] = headerValue
+		}
+
+		timestamp := ""
+		if record.HasTimestamp() {
+			timestamp = record.GetTimestamp().Format(time.RFC3339Nano)
+		}
+
+		rs[i] = map[string]interface{}{
+			PartitionField:     int(record.GetPartition()),
+			OffsetField:        int(record.GetOffset()),
+			TimestampField:     timestamp,
+			TimestampTypeField: record.GetTimestampType(),
+			KeyField:           key,
+			ValueField:         value,
+			HeadersField:       headers,
+		}
+	}
+
+	return rs
+}
@@ -0,0 +1,87 @@
+package topic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceTopicRecordsRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		query  string
+	}{
+		{
+			name:   "defaults",
+			config: map[string]interface{}{},
+			query:  "limit=20",
+		},
+		{
+			name:   "first partition from an offset",
+			config: map[string]interface{}{PartitionField: 0, OffsetField: 5, LimitField: 3},
+			query:  "limit=3&offset=5&partition=0",
+		},
+		{
+			name:   "from a timestamp",
+			config: map[string]interface{}{TimestampField: "2022-01-02T03:04:05Z", MaxValueLengthField: 10},
+			query:  "limit=20&maxValueLength=10&timestamp=2022-01-02T03%3A04%3A05Z",
+		},
+	}
+
+	localizer, _ := goi18n.New(nil)
+	r := DataSourceTopicRecords(localizer)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			factory := fakeapi.KafkaInstanceFactory(t, func(w http.ResponseWriter, req *http.Request) {
+				query = req.URL.RawQuery
+
+				list := kafkainstanceclient.NewRecordListWithDefaults()
+				list.SetItems([]kafkainstanceclient.Record{})
+				fakeapi.WriteJSON(w, http.StatusOK, list)
+			})
+
+			config := map[string]interface{}{KafkaIDField: "test-kafka-id", TopicField: "test-topic"}
+			for k, v := range tt.config { // nolint:scopelint
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+			diags := r.ReadContext(context.Background(), d, factory)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.query, query) // nolint:scopelint
+			assert.Equal(t, "test-kafka-id/test-topic", d.Id())
+		})
+	}
+}
+
+func TestFlattenRecords(t *testing.T) {
+	record := kafkainstanceclient.NewRecord("value")
+	record.SetKey("key")
+	record.SetPartition(1)
+	record.SetOffset(2)
+	record.SetTimestamp(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC))
+	record.SetTimestampType("CreateTime")
+	record.SetHeaders(map[string]string{"source": "test"})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			PartitionField:     1,
+			OffsetField:        2,
+			TimestampField:     "2022-01-02T03:04:05Z",
+			TimestampTypeField: "CreateTime",
+			KeyField:           "a2V5",
+			ValueField:         "dmFsdWU=",
+			HeadersField:       map[string]interface{}{"source": "test"},
+		},
+	}, flattenRecords([]kafkainstanceclient.Record{*record}, true))
+}
@@ -0,0 +1,51 @@
+package topic
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+var _ function.Function = &validTopicNameFunction{}
+
+// validTopicNameFunction is the valid_topic_name provider function, checking
+// a name with the rules of the rhoas_topic resource
+type validTopicNameFunction struct {
+	localizer localize.Localizer
+}
+
+func NewValidTopicNameFunction(localizer localize.Localizer) function.Function {
+	return &validTopicNameFunction{
+		localizer: localizer,
+	}
+}
+
+func (f *validTopicNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "valid_topic_name"
+}
+
+func (f *validTopicNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.localizer.MustLocalize("topic.function.validTopicName.summary"),
+		Description: f.localizer.MustLocalize("topic.function.validTopicName.description"),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: f.localizer.MustLocalize("topic.function.validTopicName.parameter.name"),
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validTopicNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, ValidateTopicName(name) == nil)
+}
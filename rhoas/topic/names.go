@@ -0,0 +1,30 @@
+package topic
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const maxTopicNameLength = 249
+
+// topicNamePattern matches the names accepted by kafka for topics
+var topicNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ValidateTopicName returns an error when the name is not a valid name for a
+// topic: up to 249 letters, digits, periods, underscores and hyphens, other
+// than "." and ".."
+func ValidateTopicName(name string) error {
+	if len(name) > maxTopicNameLength {
+		return fmt.Errorf("the topic name %q must be at most %d characters long", name, maxTopicNameLength)
+	}
+
+	if name == "." || name == ".." {
+		return fmt.Errorf("the topic name cannot be %q", name)
+	}
+
+	if !topicNamePattern.MatchString(name) {
+		return fmt.Errorf("the topic name %q must consist of letters, digits, periods, underscores and hyphens", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package topic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTopicName(t *testing.T) {
+	assert.NoError(t, ValidateTopicName("orders"))
+	assert.NoError(t, ValidateTopicName("Orders.v1_eu-1"))
+	assert.NoError(t, ValidateTopicName(strings.Repeat("a", 249)))
+
+	assert.Error(t, ValidateTopicName(""))
+	assert.Error(t, ValidateTopicName("."))
+	assert.Error(t, ValidateTopicName(".."))
+	assert.Error(t, ValidateTopicName("orders/eu"))
+	assert.Error(t, ValidateTopicName("orders eu"))
+	assert.Error(t, ValidateTopicName(strings.Repeat("a", 250)))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
 	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
@@ -19,34 +20,43 @@ const (
 )
 
 func ResourceTopic(localizer localize.Localizer) *schema.Resource {
+	topicSchema := map[string]*schema.Schema{
+		NameField: {
+			Description:  localizer.MustLocalize("topic.resource.field.description.name"),
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: utils.StringValidateFunc(ValidateTopicName),
+		},
+		PartitionsField: {
+			Description: localizer.MustLocalize("topic.resource.field.description.partitions"),
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+		},
+		KafkaIDField: {
+			Description: localizer.MustLocalize("topic.resource.field.description.kafkaID"),
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		utils.DeletionProtectionField: utils.DeletionProtectionSchema(localizer),
+	}
+
 	return &schema.Resource{
 		Description:   "`rhoas_topic` manages a topic in a  Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
 		CreateContext: topicCreate,
 		ReadContext:   topicRead,
+		UpdateContext: topicUpdate,
 		DeleteContext: topicDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 		},
-		Schema: map[string]*schema.Schema{
-			NameField: {
-				Description: localizer.MustLocalize("topic.resource.field.description.name"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			PartitionsField: {
-				Description: localizer.MustLocalize("topic.resource.field.description.partitions"),
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-			},
-			KafkaIDField: {
-				Description: localizer.MustLocalize("topic.resource.field.description.kafkaID"),
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-		},
+		CustomizeDiff: customdiff.All(
+			utils.DeletionProtectionCustomizeDiff(topicSchema),
+			utils.ReferencesCustomizeDiff(KafkaIDField),
+		),
+		Schema: topicSchema,
 	}
 }
 
@@ -59,6 +69,11 @@ func topicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.Errorf("unable to cast %v to rhoasAPI.Factory", m)
 	}
 
+	err := utils.CheckDeletionProtection(factory, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	kafkaID, ok := d.Get(KafkaIDField).(string)
 	if !ok {
 		return diag.FromErr(factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField)))
@@ -84,6 +99,12 @@ func topicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	return diags
 }
 
+func topicUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// every argument sent to the api forces a new topic, the remaining
+	// arguments only change the behaviour of the provider
+	return topicRead(ctx, d, m)
+}
+
 func topicRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
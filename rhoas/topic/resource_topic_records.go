@@ -0,0 +1,362 @@
+package topic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+)
+
+const (
+	TopicField            = "topic"
+	RecordField           = "record"
+	KeyField              = "key"
+	ValueField            = "value"
+	HeadersField          = "headers"
+	PartitionField        = "partition"
+	OffsetField           = "offset"
+	HashField             = "hash"
+	ContentHashField      = "content_hash"
+	PublishedRecordsField = "published_records"
+
+	// lets kafka choose the partition of a record from its key
+	anyPartition = -1
+)
+
+// nolint:funlen
+func ResourceTopicRecords(localizer localize.Localizer) *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_topic_records` produces records to a topic of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, e.g. to seed it with fixture data. When the records change only the new or changed records are produced again. Records cannot be removed from a topic, so destroying the resource leaves the records in place. The records are not read back from the topic, so records removed by the retention of the topic or by other clients are not detected and not produced again. When producing a record fails, the records produced so far are kept and the next apply only produces the missing records, creating the resource then only warns so that it is not replaced.",
+		CreateContext: topicRecordsCreate,
+		ReadContext:   topicRecordsRead,
+		UpdateContext: topicRecordsUpdate,
+		DeleteContext: topicRecordsDelete,
+		CustomizeDiff: topicRecordsCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			KafkaIDField: {
+				Description: localizer.MustLocalize("topic.resource.field.description.kafkaID"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			TopicField: {
+				Description: localizer.MustLocalize("topic.records.field.description.topic"),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			RecordField: {
+				Description: localizer.MustLocalize("topic.records.field.description.record"),
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						KeyField: {
+							Description: localizer.MustLocalize("topic.records.field.description.key"),
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						ValueField: {
+							Description: localizer.MustLocalize("topic.records.field.description.value"),
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						HeadersField: {
+							Description: localizer.MustLocalize("topic.records.field.description.headers"),
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						PartitionField: {
+							Description:  localizer.MustLocalize("topic.records.field.description.partition"),
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      anyPartition,
+							ValidateFunc: validation.IntAtLeast(anyPartition),
+						},
+					},
+				},
+			},
+			ContentHashField: {
+				Description: localizer.MustLocalize("topic.records.field.description.contentHash"),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			PublishedRecordsField: {
+				Description: localizer.MustLocalize("topic.records.field.description.publishedRecords"),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						HashField: {
+							Description: localizer.MustLocalize("topic.records.field.description.hash"),
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						PartitionField: {
+							Description: localizer.MustLocalize("topic.records.field.description.publishedPartition"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						OffsetField: {
+							Description: localizer.MustLocalize("topic.records.field.description.offset"),
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func topicRecordsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("%s/%s", d.Get(KafkaIDField), d.Get(TopicField)))
+
+	err := produceTopicRecords(ctx, d, m)
+	if err == nil {
+		return nil
+	}
+
+	factory, ok := m.(rhoasAPI.Factory)
+	published, _ := d.Get(PublishedRecordsField).([]interface{})
+	if !ok || len(published) == 0 {
+		// nothing was produced, so the resource is not created
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	// an error would taint the resource and its replacement would produce the
+	// records again, so the records produced are kept with a warning and the
+	// next apply produces the others
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  factory.Localizer().MustLocalize("topic.records.errors.notProduced"),
+		Detail:   err.Error(),
+	}}
+}
+
+func topicRecordsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.FromErr(produceTopicRecords(ctx, d, m))
+}
+
+// topicRecordsRead keeps the state as it is. The records are not read back, as
+// the topic may hold other records and its retention may remove them, so drift
+// is never detected
+func topicRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func topicRecordsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// records cannot be deleted from a topic, they are only removed from the state
+	d.SetId("")
+	return diags
+}
+
+// topicRecordsCustomizeDiff marks the published records as changing whenever
+// the records change, as only the apply knows which records are produced
+func topicRecordsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange(RecordField) {
+		return nil
+	}
+
+	if err := d.SetNewComputed(ContentHashField); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed(PublishedRecordsField)
+}
+
+// produceTopicRecords produces the records of the resource which were not
+// published already and records the published records in the state. When
+// producing a record fails the previous records are kept in the state along
+// with every published record, so that the next apply produces the records
+// which are still missing and only those
+func produceTopicRecords(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	factory, ok := m.(rhoasAPI.Factory)
+	if !ok {
+		return fmt.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+	}
+
+	kafkaID, ok := d.Get(KafkaIDField).(string)
+	if !ok {
+		return factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", KafkaIDField))
+	}
+
+	topic, ok := d.Get(TopicField).(string)
+	if !ok {
+		return factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", TopicField))
+	}
+
+	records, ok := d.Get(RecordField).([]interface{})
+	if !ok {
+		return factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RecordField))
+	}
+
+	previousInput, _ := d.GetChange(PublishedRecordsField)
+	previous, ok := previousInput.([]interface{})
+	if !ok {
+		return factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", PublishedRecordsField))
+	}
+
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, kafkaID)
+	if err != nil {
+		return err
+	}
+
+	published, err := produceRecords(ctx, factory, instanceAPI, topic, records, previous)
+	if setErr := d.Set(PublishedRecordsField, published); setErr != nil {
+		return setErr
+	}
+
+	if err != nil {
+		oldRecords, _ := d.GetChange(RecordField)
+		oldContentHash, _ := d.GetChange(ContentHashField)
+		if setErr := d.Set(RecordField, oldRecords); setErr != nil {
+			return setErr
+		}
+		if setErr := d.Set(ContentHashField, oldContentHash); setErr != nil {
+			return setErr
+		}
+
+		return err
+	}
+
+	return d.Set(ContentHashField, recordsHash(published))
+}
+
+// produceRecords produces the records whose hash is not part of the previously
+// published records, reusing the previous offsets for the others. The published
+// records are returned in the order of the records. On error the previously
+// published records which were not reused yet are returned after the records
+// published so far, so that they are not produced again
+func produceRecords(ctx context.Context, factory rhoasAPI.Factory, instanceAPI *kafkainstanceclient.APIClient, topic string, records []interface{}, previous []interface{}) ([]interface{}, error) {
+	// the records already published by their hash, a record given twice is
+	// only reused once so that it is produced as many times as it is given
+	available := map[string][]interface{}{}
+	for _, p := range previous {
+		if publishedRecord, ok := p.(map[string]interface{}); ok {
+			hash, _ := publishedRecord[HashField].(string)
+			available[hash] = append(available[hash], publishedRecord)
+		}
+	}
+
+	published := make([]interface{}, 0, len(records))
+	for _, input := range records {
+		record, ok := input.(map[string]interface{})
+		if !ok {
+			return append(published, unusedRecords(previous, available)...), factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", RecordField))
+		}
+
+		hash := recordHash(record)
+		if len(available[hash]) > 0 {
+			published = append(published, available[hash][0])
+			available[hash] = available[hash][1:]
+			continue
+		}
+
+		produced, resp, err := instanceAPI.RecordsApi.ProduceRecord(ctx, topic).Record(mapRecordToAPI(record)).Execute()
+		if apiErr := utils.GetAPIError(factory, resp, err); apiErr != nil {
+			return append(published, unusedRecords(previous, available)...), apiErr
+		}
+
+		published = append(published, map[string]interface{}{
+			HashField:      hash,
+			PartitionField: int(produced.GetPartition()),
+			OffsetField:    int(produced.GetOffset()),
+		})
+	}
+
+	return published, nil
+}
+
+// unusedRecords returns the previously published records which are still
+// available, in their previous order. The records of a hash are reused from the
+// first one, so the available records of a hash are its last ones
+func unusedRecords(previous []interface{}, available map[string][]interface{}) []interface{} {
+	remaining := map[string]int{}
+	for _, p := range previous {
+		if publishedRecord, ok := p.(map[string]interface{}); ok {
+			hash, _ := publishedRecord[HashField].(string)
+			remaining[hash]++
+		}
+	}
+
+	unused := make([]interface{}, 0)
+	for _, p := range previous {
+		publishedRecord, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		hash, _ := publishedRecord[HashField].(string)
+		if remaining[hash] <= len(available[hash]) {
+			unused = append(unused, publishedRecord)
+		}
+		remaining[hash]--
+	}
+
+	return unused
+}
+
+func mapRecordToAPI(record map[string]interface{}) kafkainstanceclient.Record {
+	value, _ := record[ValueField].(string)
+	apiRecord := kafkainstanceclient.NewRecord(value)
+
+	if key, _ := record[KeyField].(string); key != "" {
+		apiRecord.SetKey(key)
+	}
+
+	if headersInput, _ := record[HeadersField].(map[string]interface{}); len(headersInput) > 0 {
+		headers := make(map[string]string, len(headersInput))
+		for name, headerValue := range headersInput {
+			headers[name], _ = headerValue.(string)
+		}
+		apiRecord.SetHeaders(headers)
+	}
+
+	if partition, _ := record[PartitionField].(int); partition != anyPartition {
+		apiRecord.SetPartition(int32(partition))
+	}
+
+	return *apiRecord
+}
+
+// recordHash returns the hash of the content of a record
+func recordHash(record map[string]interface{}) string {
+	// the record sent to the api holds the whole content of the record and
+	// its headers are sorted when marshalled, so it is hashed as json
+	data, _ := json.Marshal(mapRecordToAPI(record))
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// recordsHash returns the hash of the published records
+func recordsHash(published []interface{}) string {
+	hash := sha256.New()
+	for _, p := range published {
+		if publishedRecord, ok := p.(map[string]interface{}); ok {
+			recordHash, _ := publishedRecord[HashField].(string)
+			hash.Write([]byte(recordHash))
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
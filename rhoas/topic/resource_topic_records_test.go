@@ -0,0 +1,230 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/fakeapi"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecord(key string, value string) map[string]interface{} {
+	return map[string]interface{}{
+		KeyField:       key,
+		ValueField:     value,
+		HeadersField:   map[string]interface{}{},
+		PartitionField: anyPartition,
+	}
+}
+
+func TestRecordHash(t *testing.T) {
+	record := testRecord("key", "value")
+	record[HeadersField] = map[string]interface{}{"a": "1", "b": "2"}
+
+	same := testRecord("key", "value")
+	same[HeadersField] = map[string]interface{}{"b": "2", "a": "1"}
+	assert.Equal(t, recordHash(record), recordHash(same))
+
+	for name, changed := range map[string]map[string]interface{}{
+		"key":       testRecord("other", "value"),
+		"value":     testRecord("key", "other"),
+		"headers":   testRecord("key", "value"),
+		"partition": {KeyField: "key", ValueField: "value", PartitionField: 1},
+	} {
+		assert.NotEqual(t, recordHash(record), recordHash(changed), name)
+	}
+}
+
+func TestProduceRecords(t *testing.T) {
+	var produced []string
+	factory := fakeapi.KafkaInstanceFactory(t, testRecordsAdmin(&produced, ""))
+
+	ctx := context.Background()
+	instanceAPI, _, err := factory.KafkaAdmin(&ctx, "test-kafka-id")
+	assert.NoError(t, err)
+
+	unchanged := testRecord("a", "unchanged")
+	previous := []interface{}{
+		map[string]interface{}{HashField: recordHash(unchanged), PartitionField: 0, OffsetField: 7},
+		map[string]interface{}{HashField: recordHash(testRecord("b", "old")), PartitionField: 0, OffsetField: 8},
+	}
+	records := []interface{}{
+		unchanged,
+		testRecord("b", "new"),
+		unchanged,
+	}
+
+	published, err := produceRecords(ctx, factory, instanceAPI, "test-topic", records, previous)
+	assert.NoError(t, err)
+
+	// the unchanged record is reused once, its second copy is produced
+	assert.Equal(t, []string{"new", "unchanged"}, produced)
+	assert.Equal(t, []interface{}{
+		previous[0],
+		map[string]interface{}{HashField: recordHash(testRecord("b", "new")), PartitionField: 0, OffsetField: 101},
+		map[string]interface{}{HashField: recordHash(unchanged), PartitionField: 0, OffsetField: 102},
+	}, published)
+}
+
+// testRecordsAdmin returns a handler producing the records to test-topic and
+// failing for the records with the given value
+func testRecordsAdmin(produced *[]string, failing string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/topics/test-topic/records" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var record kafkainstanceclient.Record
+		_ = json.NewDecoder(r.Body).Decode(&record)
+		if record.GetValue() == failing {
+			fakeapi.WriteJSON(w, http.StatusInternalServerError, map[string]interface{}{"code": 500, "error_message": "unable to produce the record"})
+			return
+		}
+		*produced = append(*produced, record.GetValue())
+
+		record.SetPartition(0)
+		record.SetOffset(int64(100 + len(*produced)))
+		fakeapi.WriteJSON(w, http.StatusCreated, record)
+	}
+}
+
+func testRecordsConfig(values ...string) *terraform.ResourceConfig {
+	records := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		records = append(records, map[string]interface{}{ValueField: value})
+	}
+
+	return terraform.NewResourceConfigRaw(map[string]interface{}{
+		KafkaIDField: fakeapi.KafkaID,
+		TopicField:   "test-topic",
+		RecordField:  records,
+	})
+}
+
+func TestTopicRecordsCreateFailure(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceTopicRecords(localizer)
+	ctx := context.Background()
+
+	var produced []string
+	factory := fakeapi.KafkaInstanceFactory(t, testRecordsAdmin(&produced, "failing"))
+
+	diff, err := r.Diff(ctx, nil, testRecordsConfig("a", "failing"), factory)
+	assert.NoError(t, err)
+
+	state, diags := r.Apply(ctx, nil, diff, factory)
+	assert.False(t, diags.HasError(), "expected the created resource not to be tainted: %v", diags)
+	if assert.Len(t, diags, 1, "expected a warning") {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+	}
+	if !assert.NotNil(t, state) {
+		return
+	}
+	assert.Equal(t, fakeapi.KafkaID+"/test-topic", state.ID, "expected the created resource to be kept")
+	assert.Equal(t, "0", state.Attributes[RecordField+".#"], "expected the records to be produced again by the next apply")
+	assert.Equal(t, "1", state.Attributes[PublishedRecordsField+".#"], "expected the produced record to be written")
+	assert.Equal(t, []string{"a"}, produced)
+
+	diff, err = r.Diff(ctx, state, testRecordsConfig("a", "b"), factory)
+	assert.NoError(t, err)
+	assert.False(t, diff.RequiresNew(), "expected the resource not to be replaced")
+
+	_, diags = r.Apply(ctx, state, diff, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"a", "b"}, produced, "expected only the missing record to be produced")
+}
+
+func TestTopicRecordsCreateFailureWithoutRecords(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceTopicRecords(localizer)
+	ctx := context.Background()
+
+	var produced []string
+	factory := fakeapi.KafkaInstanceFactory(t, testRecordsAdmin(&produced, "failing"))
+
+	diff, err := r.Diff(ctx, nil, testRecordsConfig("failing"), factory)
+	assert.NoError(t, err)
+
+	state, diags := r.Apply(ctx, nil, diff, factory)
+	assert.True(t, diags.HasError(), "expected producing the failing record to fail")
+	if state != nil {
+		assert.Equal(t, "", state.ID, "expected the resource not to be created")
+	}
+}
+
+func TestTopicRecordsUpdateFailure(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceTopicRecords(localizer)
+	ctx := context.Background()
+
+	var produced []string
+	factory := fakeapi.KafkaInstanceFactory(t, testRecordsAdmin(&produced, "failing"))
+
+	diff, err := r.Diff(ctx, nil, testRecordsConfig("a"), factory)
+	assert.NoError(t, err)
+
+	state, diags := r.Apply(ctx, nil, diff, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	diff, err = r.Diff(ctx, state, testRecordsConfig("a", "b", "failing"), factory)
+	assert.NoError(t, err)
+
+	updated, diags := r.Apply(ctx, state, diff, factory)
+	assert.True(t, diags.HasError(), "expected producing the failing record to fail")
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, "1", updated.Attributes[RecordField+".#"], "expected the previous records to be kept")
+		assert.Equal(t, "2", updated.Attributes[PublishedRecordsField+".#"], "expected the produced records to be written")
+		assert.Equal(t, "101", updated.Attributes[PublishedRecordsField+".0."+OffsetField])
+		assert.Equal(t, "102", updated.Attributes[PublishedRecordsField+".1."+OffsetField])
+	}
+	assert.Equal(t, []string{"a", "b"}, produced)
+
+	diff, err = r.Diff(ctx, updated, testRecordsConfig("a", "b"), factory)
+	assert.NoError(t, err)
+
+	_, diags = r.Apply(ctx, updated, diff, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"a", "b"}, produced, "expected the produced records not to be produced again")
+}
+
+func TestTopicRecordsUpdateFailureKeepsPublishedRecords(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	r := ResourceTopicRecords(localizer)
+	ctx := context.Background()
+
+	var produced []string
+	factory := fakeapi.KafkaInstanceFactory(t, testRecordsAdmin(&produced, "failing"))
+
+	diff, err := r.Diff(ctx, nil, testRecordsConfig("a", "b", "c"), factory)
+	assert.NoError(t, err)
+
+	state, diags := r.Apply(ctx, nil, diff, factory)
+	assert.False(t, diags.HasError(), diags)
+
+	// the second record of the update fails
+	diff, err = r.Diff(ctx, state, testRecordsConfig("x", "failing", "a", "b", "c"), factory)
+	assert.NoError(t, err)
+
+	updated, diags := r.Apply(ctx, state, diff, factory)
+	assert.True(t, diags.HasError(), "expected producing the failing record to fail")
+	if !assert.NotNil(t, updated) {
+		return
+	}
+	assert.Equal(t, "3", updated.Attributes[RecordField+".#"], "expected the previous records to be kept")
+	assert.Equal(t, "4", updated.Attributes[PublishedRecordsField+".#"], "expected the unused published records to be kept")
+	assert.Equal(t, []string{"a", "b", "c", "x"}, produced)
+
+	diff, err = r.Diff(ctx, updated, testRecordsConfig("x", "a", "b", "c"), factory)
+	assert.NoError(t, err)
+
+	_, diags = r.Apply(ctx, updated, diff, factory)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"a", "b", "c", "x"}, produced, "expected the published records not to be produced again")
+}
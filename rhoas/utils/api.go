@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/restapi"
 
 	"github.com/pkg/errors"
 )
@@ -48,24 +49,37 @@ func GetAPIError(factory rhoasAPI.Factory, response *http.Response, apiError err
 
 	switch response.StatusCode {
 	case http.StatusBadRequest:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.badRequest"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.badRequest"), response, apiError))
 	case http.StatusUnauthorized:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.unauthorized"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.unauthorized"), response, apiError))
 	case http.StatusForbidden:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.forbidden"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.forbidden"), response, apiError))
 	case http.StatusInternalServerError:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.internalServerError"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.internalServerError"), response, apiError))
 	case http.StatusServiceUnavailable:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.serviceUnavailable"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.serviceUnavailable"), response, apiError))
 	case http.StatusConflict:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.conflict"), response, apiError))
+		if isRuleViolation(apiError) {
+			return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.ruleViolation"), response, apiError))
+		}
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.conflict"), response, apiError))
 	case http.StatusNotFound:
-		return fmt.Errorf(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.notFound"), response, apiError))
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.notFound"), response, apiError))
+	case http.StatusUnprocessableEntity:
+		return errors.New(buildErrorString(factory.Localizer().MustLocalize("common.errors.api.unprocessableEntity"), response, apiError))
 	}
 
 	return apiError
 }
 
+// isRuleViolation checks whether the error is a Service Registry instance
+// rejecting content which violates one of its rules, which the registry
+// reports as a conflict
+func isRuleViolation(apiError error) bool {
+	var restErr *restapi.Error
+	return errors.As(apiError, &restErr) && restErr.Name == "RuleViolationException"
+}
+
 func buildErrorString(message string, response *http.Response, apiError error) string {
 	return fmt.Sprintf("%v :: %v :: %v :: %v", message, apiError.Error(), response.Request.URL.Host+response.Request.URL.Path, response.Request.Method)
 }
@@ -47,7 +47,9 @@ func TestGetAPIError(t *testing.T) {
 	)
 
 	localizer, _ := goi18n.New(nil)
-	factory := factories.NewDefaultFactory(nil, nil, nil, localizer)
+	factory := factories.NewDefaultFactory(factories.Options{
+		Localizer: localizer,
+	})
 
 	t.Run("no response and no api error", func(t *testing.T) {
 		err := utils.GetAPIError(factory, nil, nil)
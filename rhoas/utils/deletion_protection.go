@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+// DeletionProtectionField is the argument protecting a resource from being deleted
+const DeletionProtectionField = "deletion_protection"
+
+// DeletionProtectionSchema returns the schema of the deletion protection argument
+func DeletionProtectionSchema(localizer localize.Localizer) *schema.Schema {
+	return &schema.Schema{
+		Description: localizer.MustLocalize("common.field.description.deletionProtection"),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// CheckDeletionProtection returns an error when the deletion protection of the resource is enabled
+func CheckDeletionProtection(factory rhoasAPI.Factory, d *schema.ResourceData) error {
+	protected, ok := d.Get(DeletionProtectionField).(bool)
+	if !ok {
+		return factory.Localizer().MustLocalizeError("common.errors.fieldNotFoundInSchema", localize.NewEntry("Field", DeletionProtectionField))
+	}
+
+	if protected {
+		return factory.Localizer().MustLocalizeError("common.errors.deletionProtection", localize.NewEntry("ID", d.Id()), localize.NewEntry("Field", DeletionProtectionField))
+	}
+
+	return nil
+}
+
+// DeletionProtectionCustomizeDiff returns a CustomizeDiff function refusing to plan
+// the replacement of a resource whose deletion protection is enabled. Failing
+// rather than warning is deliberate and documented in the description of the
+// field: the plugin sdk does not allow a CustomizeDiff to return warnings, and
+// the replacement would fail when deleting the resource anyway
+func DeletionProtectionCustomizeDiff(s map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		// the protection of the existing resource is the one which matters
+		protected, _ := d.GetChange(DeletionProtectionField)
+		if enabled, ok := protected.(bool); !ok || !enabled {
+			return nil
+		}
+
+		var replacedBy []string
+		for field, fieldSchema := range s {
+			if fieldSchema.ForceNew && d.HasChange(field) {
+				replacedBy = append(replacedBy, field)
+			}
+		}
+
+		if len(replacedBy) == 0 {
+			return nil
+		}
+		sort.Strings(replacedBy)
+
+		factory, ok := m.(rhoasAPI.Factory)
+		if !ok {
+			return fmt.Errorf("unable to cast %v to rhoasAPI.Factory", m)
+		}
+
+		return factory.Localizer().MustLocalizeError("common.errors.deletionProtectionReplacement", localize.NewEntry("ID", d.Id()), localize.NewEntry("Fields", strings.Join(replacedBy, ", ")), localize.NewEntry("Field", DeletionProtectionField))
+	}
+}
@@ -0,0 +1,119 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProtectedResource() *schema.Resource {
+	localizer, _ := goi18n.New(nil)
+
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		utils.DeletionProtectionField: utils.DeletionProtectionSchema(localizer),
+	}
+
+	return &schema.Resource{
+		Schema:        s,
+		CustomizeDiff: utils.DeletionProtectionCustomizeDiff(s),
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		Localizer: localizer,
+	})
+	r := testProtectedResource()
+
+	t.Run("unprotected", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+		assert.NoError(t, utils.CheckDeletionProtection(factory, d))
+	})
+
+	t.Run("protected", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo", utils.DeletionProtectionField: true})
+		d.SetId("foo-id")
+
+		err := utils.CheckDeletionProtection(factory, d)
+		assert.Error(t, err, "expected a protected resource not to be deleted")
+		assert.Contains(t, err.Error(), "foo-id")
+	})
+}
+
+func TestDeletionProtectionCustomizeDiff(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	factory := factories.NewDefaultFactory(factories.Options{
+		Localizer: localizer,
+	})
+	r := testProtectedResource()
+
+	state := func(protected string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "foo-id",
+			Attributes: map[string]string{
+				"id":                          "foo-id",
+				"name":                        "foo",
+				utils.DeletionProtectionField: protected,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		state   *terraform.InstanceState
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "creating a protected resource",
+			state:  nil,
+			config: map[string]interface{}{"name": "foo", utils.DeletionProtectionField: true},
+		},
+		{
+			name:   "updating the protection",
+			state:  state("true"),
+			config: map[string]interface{}{"name": "foo", utils.DeletionProtectionField: false},
+		},
+		{
+			name:   "replacing an unprotected resource",
+			state:  state("false"),
+			config: map[string]interface{}{"name": "bar"},
+		},
+		{
+			name:    "replacing a protected resource",
+			state:   state("true"),
+			config:  map[string]interface{}{"name": "bar", utils.DeletionProtectionField: true},
+			wantErr: true,
+		},
+		{
+			name:    "replacing while removing the protection",
+			state:   state("true"),
+			config:  map[string]interface{}{"name": "bar", utils.DeletionProtectionField: false},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), tt.state, terraform.NewResourceConfigRaw(tt.config), factory)
+			if tt.wantErr {
+				assert.Error(t, err, "expected the replacement to be refused")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
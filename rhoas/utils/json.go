@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SuppressEquivalentJSON ignores changes to an attribute holding a json
+// document which only change its formatting or the order of its keys
+func SuppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// JSONMergePatch returns the json merge patch changing the old json document
+// into the new one. The members of an object which are not part of the new
+// document are set to null, so that the patch removes them
+func JSONMergePatch(old string, new string) (json.RawMessage, error) {
+	var oldValue, newValue interface{}
+
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(oldValue, newValue))
+}
+
+func mergePatch(oldValue interface{}, newValue interface{}) interface{} {
+	oldObject, oldIsObject := oldValue.(map[string]interface{})
+	newObject, newIsObject := newValue.(map[string]interface{})
+	if !oldIsObject || !newIsObject {
+		// anything but an object replaces the previous value
+		return newValue
+	}
+
+	patch := make(map[string]interface{}, len(newObject))
+	for name, value := range newObject {
+		patch[name] = mergePatch(oldObject[name], value)
+	}
+
+	for name := range oldObject {
+		if _, ok := newObject[name]; !ok {
+			patch[name] = nil
+		}
+	}
+
+	return patch
+}
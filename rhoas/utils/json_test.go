@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressEquivalentJSON(t *testing.T) {
+	const document = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+
+	assert.True(t, utils.SuppressEquivalentJSON("content", document, `{
+  "name": "Order",
+  "type": "record",
+  "fields": [{"name": "id", "type": "string"}]
+}`, nil))
+	assert.False(t, utils.SuppressEquivalentJSON("content", document, `{"type": "string"}`, nil))
+	assert.False(t, utils.SuppressEquivalentJSON("content", `syntax = "proto3";`, `syntax = "proto2";`, nil))
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{
+			name: "changed members",
+			old:  `{"topic": "orders", "format": "json"}`,
+			new:  `{"topic": "invoices", "format": "json"}`,
+			want: `{"topic": "invoices", "format": "json"}`,
+		},
+		{
+			name: "removed members",
+			old:  `{"topic": "orders", "format": "json", "processors": {"filter": "a", "limit": 10}}`,
+			new:  `{"topic": "orders", "processors": {"filter": "a"}}`,
+			want: `{"topic": "orders", "format": null, "processors": {"filter": "a", "limit": null}}`,
+		},
+		{
+			name: "object replaced by a value",
+			old:  `{"processors": {"filter": "a"}}`,
+			new:  `{"processors": ["filter"]}`,
+			want: `{"processors": ["filter"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := utils.JSONMergePatch(tt.old, tt.new) // nolint:scopelint
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(patch)) // nolint:scopelint
+		})
+	}
+
+	_, err := utils.JSONMergePatch(`{`, `{}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "github.com/redhat-developer/terraform-provider-rhoas/rhoas/api"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize"
+)
+
+// serviceAccountClientIDPattern matches the client ids of the service
+// accounts, other principals are users which cannot be looked up
+var serviceAccountClientIDPattern = regexp.MustCompile(`^(srvc-acct-)?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// IsServiceAccountClientID returns whether the principal is the client id of a service account
+func IsServiceAccountClientID(principal string) bool {
+	return serviceAccountClientIDPattern.MatchString(principal)
+}
+
+// ReferencesCustomizeDiff returns a CustomizeDiff function failing the plan
+// when the kafka instance of the kafka id field, or the service accounts of the
+// principal fields, do not exist or are not accessible. The references are only
+// checked when the provider validates references and when they are new or
+// changed. The kafka id field may be empty and the principal fields may contain
+// a "#" to check the principal of every element of a list, e.g. "acl.#.principal".
+// The sdk does not allow a CustomizeDiff to scope an error to an attribute, so
+// the errors are prefixed with the attribute instead
+func ReferencesCustomizeDiff(kafkaIDField string, principalFields ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		factory, ok := m.(rhoasAPI.Factory)
+		if !ok || !factory.ValidateReferences() {
+			return nil
+		}
+
+		if kafkaIDField != "" {
+			if kafkaID, check := referenceToCheck(d, kafkaIDField); check {
+				if err := CheckKafkaReference(ctx, factory, kafkaIDField, kafkaID); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, field := range expandListFields(d, principalFields) {
+			principal, check := referenceToCheck(d, field)
+			if !check {
+				continue
+			}
+
+			if err := CheckPrincipalReference(ctx, factory, field, principal); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// CheckKafkaReference returns an error prefixed with the field when the kafka
+// instance with the given id does not exist or is not accessible
+func CheckKafkaReference(ctx context.Context, factory rhoasAPI.Factory, field string, kafkaID string) error {
+	found, err := factory.KafkaExists(ctx, kafkaID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+
+	if !found {
+		return factory.Localizer().MustLocalizeError("common.errors.kafkaReferenceNotFound", localize.NewEntry("Field", field), localize.NewEntry("ID", kafkaID))
+	}
+
+	return nil
+}
+
+// CheckPrincipalReference returns an error prefixed with the field when the
+// principal is the client id of a service account which does not exist, the
+// other principals are not looked up
+func CheckPrincipalReference(ctx context.Context, factory rhoasAPI.Factory, field string, principal string) error {
+	if !IsServiceAccountClientID(principal) {
+		return nil
+	}
+
+	found, err := factory.ServiceAccountExists(ctx, principal)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+
+	if !found {
+		return factory.Localizer().MustLocalizeError("common.errors.serviceAccountReferenceNotFound", localize.NewEntry("Field", field), localize.NewEntry("ClientID", principal))
+	}
+
+	return nil
+}
+
+// referenceToCheck returns the reference of the field when it is known and
+// either new or changed, unchanged references are checked when read
+func referenceToCheck(d *schema.ResourceDiff, field string) (string, bool) {
+	if !d.NewValueKnown(field) || (d.Id() != "" && !d.HasChange(field)) {
+		return "", false
+	}
+
+	reference, _ := d.Get(field).(string)
+
+	return reference, reference != ""
+}
+
+// expandListFields replaces the "#" of the fields by the index of every element of the list
+func expandListFields(d *schema.ResourceDiff, fields []string) []string {
+	expanded := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		list, element, found := strings.Cut(field, ".#.")
+		if !found {
+			expanded = append(expanded, field)
+			continue
+		}
+
+		count, _ := d.Get(list + ".#").(int)
+		for i := 0; i < count; i++ {
+			expanded = append(expanded, fmt.Sprintf("%s.%d.%s", list, i, element))
+		}
+	}
+
+	return expanded
+}
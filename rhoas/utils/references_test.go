@@ -0,0 +1,182 @@
+package utils_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	factories "github.com/redhat-developer/terraform-provider-rhoas/rhoas/factory"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/localize/goi18n"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientID    = "srvc-acct-8c2a5d3e-1b7f-4c7e-9a51-3f0c2e6d4b19"
+	deletedClientID = "0a9f6b2c-4d1e-4f8a-b3c7-5e2d8f1a6c40"
+)
+
+// testReferencesFactory returns a factory whose kafka and service account
+// management apis only know the "test-kafka" instance and the test service
+// account, along with the number of lookups made
+func testReferencesFactory(t *testing.T, validate bool) (*factories.DefaultFactory, *int32) {
+	localizer, _ := goi18n.New(nil)
+
+	var lookups int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lookups, 1)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/api/kafkas_mgmt/v1/kafkas/test-kafka":
+			kafka := kafkamgmtclient.NewKafkaRequest("test-kafka", "Kafka", "", true, true)
+			_ = json.NewEncoder(w).Encode(kafka)
+		case strings.HasPrefix(r.URL.Path, "/api/kafkas_mgmt/"):
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"code": "KAFKAS-MGMT-7", "reason": "kafka not found"})
+		default:
+			accounts := make([]serviceAccounts.ServiceAccountData, 0)
+			if r.URL.Query().Get("clientId") == testClientID {
+				account := serviceAccounts.NewServiceAccountData()
+				account.SetClientId(testClientID)
+				accounts = append(accounts, *account)
+			}
+			_ = json.NewEncoder(w).Encode(accounts)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	})
+
+	serviceAccountConfig := serviceAccounts.NewConfiguration()
+	serviceAccountConfig.Servers = serviceAccounts.ServerConfigurations{{URL: server.URL}}
+	serviceAccountConfig.HTTPClient = server.Client()
+
+	factory := factories.NewDefaultFactory(factories.Options{
+		KafkaClient:          kafkaClient,
+		ServiceAccountClient: serviceAccounts.NewAPIClient(serviceAccountConfig),
+		HTTPClient:           server.Client(),
+		Localizer:            localizer,
+		ValidateReferences:   validate,
+	})
+
+	return factory, &lookups
+}
+
+func testReferencingResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"kafka_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"principal": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"acl": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"principal": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: utils.ReferencesCustomizeDiff("kafka_id", "principal", "acl.#.principal"),
+	}
+}
+
+func TestReferencesCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "existing references",
+			config: map[string]interface{}{"kafka_id": "test-kafka", "principal": testClientID},
+		},
+		{
+			name:    "missing kafka instance",
+			config:  map[string]interface{}{"kafka_id": "other-org-kafka"},
+			wantErr: `kafka_id: the Kafka instance "other-org-kafka"`,
+		},
+		{
+			name:    "deleted service account",
+			config:  map[string]interface{}{"kafka_id": "test-kafka", "principal": deletedClientID},
+			wantErr: `principal: no service account of your organization has the client ID "` + deletedClientID + `"`,
+		},
+		{
+			name:   "users and wildcards are not looked up",
+			config: map[string]interface{}{"principal": "*", "acl": []interface{}{map[string]interface{}{"principal": "jdoe"}}},
+		},
+		{
+			name: "deleted service account of a list",
+			config: map[string]interface{}{"acl": []interface{}{
+				map[string]interface{}{"principal": testClientID},
+				map[string]interface{}{"principal": deletedClientID},
+			}},
+			wantErr: "acl.1.principal: ",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			factory, _ := testReferencesFactory(t, true)
+
+			_, err := testReferencingResource().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), factory)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReferencesCustomizeDiffDisabled(t *testing.T) {
+	factory, lookups := testReferencesFactory(t, false)
+
+	_, err := testReferencingResource().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{"kafka_id": "other-org-kafka"}), factory)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *lookups, "expected no lookup without validate_references")
+}
+
+func TestReferencesCustomizeDiffMemoized(t *testing.T) {
+	factory, lookups := testReferencesFactory(t, true)
+	r := testReferencingResource()
+
+	for i := 0; i < 3; i++ {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{"kafka_id": "test-kafka", "principal": testClientID}), factory)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), *lookups, "expected the kafka instance and the service account to be looked up once")
+}
+
+func TestIsServiceAccountClientID(t *testing.T) {
+	assert.True(t, utils.IsServiceAccountClientID(testClientID))
+	assert.True(t, utils.IsServiceAccountClientID(deletedClientID))
+	assert.False(t, utils.IsServiceAccountClientID("*"))
+	assert.False(t, utils.IsServiceAccountClientID("jdoe"))
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// StringValidateFunc returns a schema validation function checking the value
+// of a string attribute with the given function, so that the same rules are
+// shared by the resources and the provider functions
+func StringValidateFunc(validate func(string) error) schema.SchemaValidateFunc {
+	return func(v interface{}, key string) ([]string, []error) {
+		value, ok := v.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+		}
+
+		if err := validate(value); err != nil {
+			return nil, []error{fmt.Errorf("%s: %w", key, err)}
+		}
+
+		return nil, nil
+	}
+}
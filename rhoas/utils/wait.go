@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// notFoundChecks is the number of refreshes in a row which may find no
+// resource before the wait fails, as for a resource.StateChangeConf
+const notFoundChecks = 20
+
+// StateChangeConf waits for a resource to reach one of the target states. It
+// waits PollInterval before refreshing the state for the first time and doubles
+// the wait after every refresh, up to PollMaxInterval. It fails with the errors
+// of a resource.StateChangeConf, so that they are handled the same way
+type StateChangeConf struct {
+	Pending         []string
+	Target          []string
+	Refresh         resource.StateRefreshFunc
+	Timeout         time.Duration
+	PollInterval    time.Duration
+	PollMaxInterval time.Duration
+}
+
+func NewStateChangeConf(pending []string, target []string, refresh resource.StateRefreshFunc, timeout time.Duration, pollInterval time.Duration, pollMaxInterval time.Duration) *StateChangeConf {
+	return &StateChangeConf{
+		Pending:         pending,
+		Target:          target,
+		Refresh:         refresh,
+		Timeout:         timeout,
+		PollInterval:    pollInterval,
+		PollMaxInterval: pollMaxInterval,
+	}
+}
+
+// WaitForStateContext refreshes the state until it is one of the target states
+// and returns the result of the last refresh
+func (c *StateChangeConf) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	deadline := time.Now().Add(c.Timeout)
+	wait := c.PollInterval
+	lastState := ""
+	notFound := 0
+
+	for {
+		// the last wait is cut short so that the timeout is reported on time
+		timer := time.NewTimer(min(wait, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil, &resource.TimeoutError{
+				LastState:     lastState,
+				Timeout:       c.Timeout,
+				ExpectedState: c.Target,
+			}
+		}
+
+		result, state, err := c.Refresh()
+		if err != nil {
+			return result, err
+		}
+
+		switch {
+		case result == nil && len(c.Target) == 0:
+			return nil, nil
+		case result == nil:
+			notFound++
+			if notFound > notFoundChecks {
+				return nil, &resource.NotFoundError{Retries: notFound}
+			}
+		case contains(c.Target, state):
+			return result, nil
+		case len(c.Pending) > 0 && !contains(c.Pending, state):
+			return result, &resource.UnexpectedStateError{
+				State:         state,
+				ExpectedState: c.Target,
+			}
+		default:
+			notFound = 0
+		}
+
+		lastState = state
+		wait = c.backoff(wait)
+	}
+}
+
+// backoff returns the wait before the refresh following a wait of the given duration
+func (c *StateChangeConf) backoff(wait time.Duration) time.Duration {
+	wait *= 2
+	if wait > c.PollMaxInterval {
+		wait = c.PollMaxInterval
+	}
+
+	return wait
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/redhat-developer/terraform-provider-rhoas/rhoas/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStateChangeConf(t *testing.T) {
+	t.Run("backs off up to the max interval", func(t *testing.T) {
+		var refreshes []time.Time
+		conf := utils.NewStateChangeConf([]string{"pending"}, []string{"done"}, func() (interface{}, string, error) {
+			refreshes = append(refreshes, time.Now())
+			if len(refreshes) < 6 {
+				return len(refreshes), "pending", nil
+			}
+			return len(refreshes), "done", nil
+		}, time.Minute, 20*time.Millisecond, 40*time.Millisecond)
+
+		start := time.Now()
+		_, err := conf.WaitForStateContext(context.Background())
+		assert.NoError(t, err, "unexpected error waiting for the target state")
+
+		assert.GreaterOrEqual(t, refreshes[0].Sub(start), 20*time.Millisecond, "expected a wait before the first refresh")
+		for i := 1; i < len(refreshes); i++ {
+			assert.GreaterOrEqual(t, refreshes[i].Sub(refreshes[i-1]), 40*time.Millisecond, "expected the wait to be backed off")
+		}
+		// without the max interval the waits would add up to 620ms
+		assert.Less(t, time.Since(start), 500*time.Millisecond, "expected the wait to be capped")
+	})
+
+	t.Run("waits for the target state", func(t *testing.T) {
+		refreshes := 0
+		conf := utils.NewStateChangeConf([]string{"pending"}, []string{"done"}, func() (interface{}, string, error) {
+			refreshes++
+			if refreshes < 3 {
+				return refreshes, "pending", nil
+			}
+			return refreshes, "done", nil
+		}, time.Minute, time.Millisecond, 10*time.Millisecond)
+
+		result, err := conf.WaitForStateContext(context.Background())
+		assert.NoError(t, err, "unexpected error waiting for the target state")
+		assert.Equal(t, 3, result)
+	})
+
+	t.Run("fails on an unexpected state", func(t *testing.T) {
+		conf := utils.NewStateChangeConf([]string{"pending"}, []string{"done"}, func() (interface{}, string, error) {
+			return struct{}{}, "failed", nil
+		}, time.Minute, time.Millisecond, time.Millisecond)
+
+		_, err := conf.WaitForStateContext(context.Background())
+		assert.IsType(t, &resource.UnexpectedStateError{}, err)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		conf := utils.NewStateChangeConf([]string{"pending"}, []string{"done"}, func() (interface{}, string, error) {
+			return struct{}{}, "pending", nil
+		}, 50*time.Millisecond, time.Millisecond, time.Minute)
+
+		start := time.Now()
+		_, err := conf.WaitForStateContext(context.Background())
+		assert.IsType(t, &resource.TimeoutError{}, err)
+		assert.Less(t, time.Since(start), time.Second, "expected the timeout not to wait for the max interval")
+	})
+}